@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+type codeInfo struct {
+	ID          string   `json:"id"`
+	Message     string   `json:"message"`
+	Severity    string   `json:"severity"`
+	Linters     []string `json:"linters,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Remediation string   `json:"remediation,omitempty"`
+	Example     string   `json:"example,omitempty"`
+}
+
+func newCodeInfo(id rules.ID, c *rules.Code) codeInfo {
+	return codeInfo{
+		ID:          "POP-" + id.String(),
+		Message:     c.Message,
+		Severity:    c.Severity.ToHumanLevel(),
+		Linters:     c.Linters,
+		Description: c.Description,
+		Remediation: c.Remediation,
+		Example:     c.Example,
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(codesCmd())
+}
+
+func codesCmd() *cobra.Command {
+	var linter, severity, out string
+	cmd := cobra.Command{
+		Use:   "codes",
+		Short: "Lists lint codes",
+		Long:  "Lists lint codes with their effective severity once spinach overrides are applied",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			level, err := toSeverity(severity)
+			if err != nil {
+				return err
+			}
+			cc, err := loadCodes()
+			if err != nil {
+				return err
+			}
+			ids := cc.Filter(linter, level)
+			switch out {
+			case "json":
+				ii := make([]codeInfo, 0, len(ids))
+				for _, id := range ids {
+					ii = append(ii, newCodeInfo(id, cc.Glossary[id]))
+				}
+				return printJSON(os.Stdout, ii)
+			case "table":
+				return printCodes(os.Stdout, cc, ids)
+			default:
+				return fmt.Errorf("invalid output format %q. [table,json]", out)
+			}
+		},
+	}
+	initSpinachFlags(&cmd)
+	cmd.Flags().StringVarP(&linter, "linter", "", "", "Only list codes reported by the given linter ie pods")
+	cmd.Flags().StringVarP(&severity, "severity", "", "", "Only list codes with the given severity [info,warn,error]")
+	cmd.Flags().StringVarP(&out, "out", "o", "table", "Specify the output format [table,json]")
+
+	return &cmd
+}
+
+// loadCodes returns lint codes refined by the spinach overrides.
+func loadCodes() (*issues.Codes, error) {
+	if err := flags.Validate(); err != nil {
+		return nil, err
+	}
+	cfg, err := config.NewConfig(flags)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := issues.LoadCodes()
+	if err != nil {
+		return nil, err
+	}
+	cc.Refine(cfg.Overrides)
+
+	return cc, nil
+}
+
+func toSeverity(s string) (rules.Level, error) {
+	switch s {
+	case "":
+		return rules.OkLevel, nil
+	case "info", "warn", "error":
+		return rules.ToIssueLevel(&s), nil
+	default:
+		return rules.OkLevel, fmt.Errorf("invalid severity %q. [info,warn,error]", s)
+	}
+}
+
+func printCodes(w io.Writer, cc *issues.Codes, ids []rules.ID) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CODE\tSEVERITY\tLINTERS\tMESSAGE")
+	for _, id := range ids {
+		c := cc.Glossary[id]
+		ll := "*"
+		if len(c.Linters) > 0 {
+			ll = strings.Join(c.Linters, ",")
+		}
+		fmt.Fprintf(tw, "POP-%d\t%s\t%s\t%s\n", id, c.Severity.ToHumanLevel(), ll, c.Message)
+	}
+
+	return tw.Flush()
+}
+
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(v)
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(configCmd())
+}
+
+func configCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "config",
+		Short: "Manages spinach configurations",
+		Long:  "Manages Popeye spinach configurations",
+	}
+	cmd.AddCommand(configDumpCmd())
+
+	return &cmd
+}
+
+func configDumpCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "dump",
+		Short: "Prints the effective spinach configuration",
+		Long:  "Prints the effective spinach configuration once all files and includes are merged",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.NewConfig(flags)
+			if err != nil {
+				return err
+			}
+
+			return cfg.Dump(os.Stdout)
+		},
+	}
+	initSpinachFlags(&cmd)
+
+	return &cmd
+}
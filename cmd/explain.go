@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(explainCmd())
+}
+
+func explainCmd() *cobra.Command {
+	var out string
+	cmd := cobra.Command{
+		Use:     "explain CODE",
+		Short:   "Explains a lint code",
+		Long:    "Explains a lint code, why it matters and how to address it",
+		Example: "  popeye explain POP-1204",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := issues.ParseID(args[0])
+			if err != nil {
+				return err
+			}
+			cc, err := loadCodes()
+			if err != nil {
+				return err
+			}
+			c, ok := cc.Glossary[id]
+			if !ok {
+				return fmt.Errorf("unknown code %q. Use `popeye codes` to list them all", args[0])
+			}
+			info := newCodeInfo(id, c)
+			switch out {
+			case "json":
+				return printJSON(os.Stdout, info)
+			case "text":
+				printExplain(os.Stdout, info)
+				return nil
+			default:
+				return fmt.Errorf("invalid output format %q. [text,json]", out)
+			}
+		},
+	}
+	initSpinachFlags(&cmd)
+	cmd.Flags().StringVarP(&out, "out", "o", "text", "Specify the output format [text,json]")
+
+	return &cmd
+}
+
+func printExplain(w io.Writer, c codeInfo) {
+	ll := "all"
+	if len(c.Linters) > 0 {
+		ll = strings.Join(c.Linters, ", ")
+	}
+	fmt.Fprintf(w, "%s: %s\n\n", c.ID, c.Message)
+	fmt.Fprintf(w, "Severity: %s\n", c.Severity)
+	fmt.Fprintf(w, "Linters:  %s\n", ll)
+	printBlock(w, "Description", c.Description)
+	printBlock(w, "Remediation", c.Remediation)
+	printBlock(w, "Example", c.Example)
+}
+
+func printBlock(w io.Writer, title, body string) {
+	if body == "" {
+		return
+	}
+	fmt.Fprintf(w, "\n%s:\n", title)
+	for _, l := range strings.Split(body, "\n") {
+		fmt.Fprintf(w, "  %s\n", l)
+	}
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/derailed/popeye/internal/history"
+	"github.com/derailed/popeye/pkg"
+	"github.com/spf13/cobra"
+)
+
+type trendInfo struct {
+	history.Target
+	Scans  []scanInfo      `json:"scans"`
+	Movers []history.Mover `json:"movers,omitempty"`
+}
+
+type scanInfo struct {
+	Timestamp time.Time `json:"timestamp"`
+	Score     int       `json:"score"`
+	Grade     string    `json:"grade"`
+	Delta     int       `json:"delta"`
+	Added     int       `json:"added"`
+	Fixed     int       `json:"fixed"`
+}
+
+func newTrendInfo(t history.Target, ss []history.Scan, top int) trendInfo {
+	info := trendInfo{Target: t, Scans: make([]scanInfo, 0, len(ss))}
+	for i, s := range ss {
+		si := scanInfo{Timestamp: s.Timestamp, Score: s.Score, Grade: s.Grade}
+		if i > 0 {
+			si.Delta = s.Score - ss[i-1].Score
+			si.Added, si.Fixed = history.Churn(ss[i-1], s)
+		}
+		info.Scans = append(info.Scans, si)
+	}
+	if len(ss) > 1 {
+		info.Movers = history.Movers(ss[0], ss[len(ss)-1], top)
+	}
+
+	return info
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd())
+}
+
+func historyCmd() *cobra.Command {
+	var (
+		cluster, context, out string
+		limit, top            int
+	)
+	cmd := cobra.Command{
+		Use:   "history",
+		Short: "Shows scan score trends",
+		Long:  "Shows score trends and the biggest linter movers from scans recorded with --history",
+		Example: "  popeye --history\n" +
+			"  popeye history --context my-ctx --limit 20",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if out != "table" && out != "json" {
+				return fmt.Errorf("invalid output format %q. [table,json]", out)
+			}
+			if _, err := os.Stat(pkg.HistoryFile()); err != nil {
+				return fmt.Errorf("%w found in %q. Use --history to record scans", history.ErrNoHistory, pkg.DumpDir)
+			}
+			s, err := history.Open(pkg.HistoryFile())
+			if err != nil {
+				return err
+			}
+			defer s.Close()
+
+			tt, err := s.Targets()
+			if err != nil {
+				return err
+			}
+			ii := make([]trendInfo, 0, len(tt))
+			for _, t := range tt {
+				if (cluster != "" && t.Cluster != cluster) || (context != "" && t.Context != context) {
+					continue
+				}
+				ss, err := s.Scans(t, limit)
+				if err != nil {
+					return err
+				}
+				ii = append(ii, newTrendInfo(t, ss, top))
+			}
+			if len(ii) == 0 {
+				return history.ErrNoHistory
+			}
+			if out == "json" {
+				return printJSON(os.Stdout, ii)
+			}
+
+			return printTrends(os.Stdout, ii)
+		},
+	}
+	cmd.Flags().StringVarP(&cluster, "cluster", "", "", "Only show scans for the given cluster")
+	cmd.Flags().StringVarP(&context, "context", "", "", "Only show scans for the given context")
+	cmd.Flags().IntVarP(&limit, "limit", "", 10, "Specify the maximum number of scans to show. Use 0 for no limit")
+	cmd.Flags().IntVarP(&top, "top", "", 5, "Specify the maximum number of linter movers to show")
+	cmd.Flags().StringVarP(&out, "out", "o", "table", "Specify the output format [table,json]")
+
+	return &cmd
+}
+
+func printTrends(w io.Writer, ii []trendInfo) error {
+	for i, info := range ii {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s (%s)\n\n", info.Cluster, info.Context)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "SCANNED\tSCORE\tGRADE\tDELTA\tNEW\tFIXED")
+		for _, s := range info.Scans {
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%+d\t%d\t%d\n", s.Timestamp.Format(time.RFC3339), s.Score, s.Grade, s.Delta, s.Added, s.Fixed)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		if len(info.Movers) == 0 {
+			continue
+		}
+		fmt.Fprintln(w, "\nBiggest movers:")
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "LINTER\tFROM\tTO\tDELTA")
+		for _, m := range info.Movers {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%+d\n", m.Linter, m.From, m.To, m.Delta)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
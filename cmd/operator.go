@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/derailed/popeye/internal/operator"
+	"github.com/derailed/popeye/pkg/storage"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+func init() {
+	rootCmd.AddCommand(operatorCmd())
+}
+
+func operatorCmd() *cobra.Command {
+	var (
+		adminNS, cluster string
+		allowed          []string
+		resync           time.Duration
+	)
+	cmd := cobra.Command{
+		Use:   "operator",
+		Short: "Runs Popeye as an operator reconciling PopeyeScan resources",
+		Long: "Watches PopeyeScan custom resources, runs the requested scans on schedule and reports their outcome in the resource status.\n" +
+			"Scans in the operator namespace may target any namespace and output location. Other scans may only target their own namespace\n" +
+			"and store reports under the allowed outputs.",
+		Example: "  kubectl apply -f k8s/popeye -f k8s/operator\n" +
+			"  popeye operator --operator-namespace popeye --allowed-outputs s3://my-bucket/popeye",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := initLogs(); err != nil {
+				return err
+			}
+			if adminNS == "" {
+				return errors.New("an operator namespace must be specified via --operator-namespace or $POD_NAMESPACE")
+			}
+			for _, a := range allowed {
+				loc, err := storage.Parse(a)
+				if err != nil {
+					return err
+				}
+				if loc.Kind == storage.FileKind {
+					return fmt.Errorf("invalid allowed output %q. File storage is reserved to the operator namespace", a)
+				}
+			}
+			cfg, err := flags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			dial, err := dynamic.NewForConfig(cfg)
+			if err != nil {
+				return err
+			}
+			clt, err := kubernetes.NewForConfig(cfg)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+			scanner := operator.NewScanner(flags, clt, cluster, adminNS, allowed)
+
+			return operator.NewController(dial, scanner, adminNS, resync).Run(ctx)
+		},
+	}
+	cmd.Flags().StringVarP(&adminNS, "operator-namespace", "", os.Getenv("POD_NAMESPACE"),
+		"Specify the operator namespace. Scans living in this namespace may target any namespace",
+	)
+	cmd.Flags().StringVarP(&cluster, "cluster-name", "", "",
+		"Specify the cluster name reported in scans",
+	)
+	cmd.Flags().StringSliceVarP(&allowed, "allowed-outputs", "", []string{},
+		"Specify the storage URIs scans outside the operator namespace may store reports under ie s3://my-bucket/popeye",
+	)
+	cmd.Flags().DurationVarP(&resync, "resync", "", 10*time.Minute,
+		"Specify how often all scans are reconciled",
+	)
+
+	return &cmd
+}
@@ -5,8 +5,10 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/derailed/popeye/internal/report"
@@ -15,6 +17,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
@@ -30,6 +33,14 @@ var (
 	}
 )
 
+var (
+	// redactedFlags tracks flags whose values are not reported.
+	redactedFlags = []string{"token", "push-gtwy-user", "push-gtwy-password"}
+
+	// urlFlags tracks flags whose url values may carry credentials.
+	urlFlags = []string{"server", "s3-endpoint", "push-gtwy-url", "otlp-endpoint"}
+)
+
 func init() {
 	initFlags()
 }
@@ -44,6 +55,7 @@ func execName() string {
 
 // Execute root command
 func Execute() {
+	pkg.Version, pkg.Commit = version, commit
 	if err := rootCmd.Execute(); err != nil {
 		return
 	}
@@ -62,22 +74,30 @@ func doIt(cmd *cobra.Command, args []string) {
 	clearScreen()
 	bomb(flags.Validate())
 	flags.StandAlone = true
+	flags.UsedFlags = usedFlags(cmd)
 	popeye, err := pkg.NewPopeye(flags, &log.Logger)
 	if err != nil {
 		bomb(fmt.Errorf("popeye configuration load failed %w", err))
 	}
 	bomb(popeye.Init())
 
-	errCount, score, err := popeye.Lint()
-	if err != nil {
+	if _, _, err := popeye.Lint(); err != nil {
 		bomb(err)
 	}
 	if flags.ForceExitZero != nil && *flags.ForceExitZero {
-		os.Exit(0)
+		os.Exit(pkg.ExitOK)
 	}
-	if errCount > 0 || (flags.MinScore != nil && score < *flags.MinScore) {
-		os.Exit(1)
+	vv := popeye.Violations()
+	if len(vv) == 0 {
+		return
+	}
+	// Console reports already list violations unless split by team.
+	if f := flags.OutputFormat(); flags.IsSplit() || (f != "standard" && f != report.JurassicFormat) {
+		for _, v := range vv {
+			fmt.Fprintf(os.Stderr, "Exit policy violated -- %s\n", v)
+		}
 	}
+	os.Exit(pkg.ExitViolation)
 }
 
 func bomb(err error) {
@@ -104,9 +124,39 @@ func initPopeyeFlags() {
 		"Force non-zero exit if the cluster score is below that threshold",
 	)
 
+	rootCmd.Flags().StringArrayVarP(flags.FailOn, "fail-on", "",
+		[]string{},
+		"Specify an exit policy as key=value pairs ie level=error,namespace=prod-* or code=1105. Keys: name, level, namespace, linter, code, max, baseline",
+	)
+
+	rootCmd.Flags().StringVarP(flags.Baseline, "baseline", "",
+		"",
+		"Specify a previous v2 json or yaml report exit policies with baseline=true are compared against",
+	)
+
 	rootCmd.Flags().StringVarP(flags.Output, "out", "o",
 		"standard",
-		"Specify the output type (standard, jurassic, yaml, json, html, junit, score)",
+		"Specify the output type (standard, jurassic, yaml, json, html, junit, csv, tsv, codequality, markdown, sarif, policyreport, score)",
+	)
+
+	rootCmd.Flags().IntVarP(flags.MarkdownMax, "markdown-max", "",
+		config.DefaultMarkdownMax,
+		"Specify the maximum number of issues listed in a markdown report. Use 0 for no limit",
+	)
+
+	rootCmd.Flags().StringVarP(flags.GroupBy, "group-by", "",
+		"section",
+		"Specify how issues are grouped in the report (section, namespace, code, owner)",
+	)
+
+	rootCmd.Flags().StringVarP(flags.SplitBy, "split-by", "",
+		"",
+		"Produce one report and score per owning team (team). Teams are configured in spinach",
+	)
+
+	rootCmd.Flags().StringVarP(flags.ReportVersion, "report-version", "",
+		"v1",
+		"Specify the json and yaml report schema version (v1, v2)",
 	)
 
 	rootCmd.Flags().BoolVarP(flags.Save, "save", "",
@@ -114,6 +164,36 @@ func initPopeyeFlags() {
 		"Specify if you want Popeye to persist the output to a file",
 	)
 
+	rootCmd.Flags().BoolVarP(flags.History, "history", "",
+		false,
+		"Specify if you want Popeye to record the scan in the local history store",
+	)
+
+	rootCmd.Flags().BoolVarP(flags.Events, "events", "",
+		false,
+		"Specify if you want Popeye to emit Kubernetes events on resources with issues",
+	)
+
+	rootCmd.Flags().BoolVarP(flags.PolicyReports, "policy-reports", "",
+		false,
+		"Specify if you want Popeye to apply wgpolicyk8s.io PolicyReports and ClusterPolicyReports to the cluster",
+	)
+
+	rootCmd.Flags().StringVarP(flags.MinAge, "min-age", "",
+		"",
+		"Only report issues first seen at least that long ago ie 7d or 12h. Tracks issues in the local history store",
+	)
+
+	rootCmd.Flags().IntVarP(flags.KeepLast, "keep-last", "",
+		0,
+		"Only keep the last N saved reports per cluster context. Use 0 to keep all reports",
+	)
+
+	rootCmd.Flags().StringVarP(flags.KeepFor, "keep-for", "",
+		"",
+		"Prune saved reports older than that ie 30d or 72h",
+	)
+
 	rootCmd.Flags().StringVarP(flags.OutputFile, "output-file", "",
 		"",
 		"Specify the file name to persist report to disk",
@@ -121,15 +201,31 @@ func initPopeyeFlags() {
 
 	rootCmd.Flags().StringVarP(flags.S3.Bucket, "s3-bucket", "",
 		"",
-		"Specify to which S3 bucket you want to save the output file",
+		"Specify a storage URI to save the output file to ie s3://bucket/dir, minio://, gs://, azblob:// or file:///dir. Defaults to s3 when no scheme is given",
 	)
 	rootCmd.Flags().StringVarP(flags.S3.Region, "s3-region", "",
 		"",
-		"Specify an s3 compatible region when the s3-bucket option is enabled",
+		"Specify the bucket region when the s3-bucket option is enabled (s3, minio)",
 	)
 	rootCmd.Flags().StringVarP(flags.S3.Endpoint, "s3-endpoint", "",
 		"",
-		"Specify an s3 compatible endpoint when the s3-bucket option is enabled",
+		"Specify the storage endpoint when the s3-bucket option is enabled ie a local emulator",
+	)
+	rootCmd.Flags().BoolVarP(flags.S3.Insecure, "s3-insecure", "",
+		false,
+		"Use plain http when the s3-endpoint does not specify a scheme",
+	)
+	rootCmd.Flags().StringVarP(flags.S3.SSE, "s3-sse", "",
+		"",
+		"Specify the server side encryption algorithm ie AES256 or aws:kms (s3, minio)",
+	)
+	rootCmd.Flags().StringVarP(flags.S3.KMSKeyID, "s3-kms-key", "",
+		"",
+		"Specify the encryption key id (s3, gs) or encryption scope (azblob) used to store the output file",
+	)
+	rootCmd.Flags().StringSliceVarP(flags.S3.Tags, "s3-tags", "",
+		[]string{},
+		"Specify tags applied to the stored output file ie team=ops,env=prod",
 	)
 
 	rootCmd.Flags().StringVarP(flags.InClusterName, "cluster-name", "",
@@ -139,7 +235,7 @@ func initPopeyeFlags() {
 
 	rootCmd.Flags().StringVarP(flags.LintLevel, "lint", "l",
 		"ok",
-		"Specify a lint level (ok, info, warn, error)",
+		"Specify a lint level (ok, info, warn, error) globally and/or per linter ie warn,nodes=error",
 	)
 
 	rootCmd.PersistentFlags().BoolVarP(flags.ClearScreen, "clear", "c",
@@ -157,10 +253,7 @@ func initPopeyeFlags() {
 		"When present, runs linters for all namespaces",
 	)
 
-	rootCmd.Flags().StringVarP(flags.Spinach, "file", "f",
-		"",
-		"Use a spinach YAML configuration file",
-	)
+	initSpinachFlags(rootCmd)
 
 	rootCmd.Flags().StringSliceVarP(flags.Sections, "sections", "s",
 		[]string{},
@@ -177,6 +270,17 @@ func initPopeyeFlags() {
 	)
 }
 
+func initSpinachFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVarP(flags.SpinachLayers, "file", "f",
+		[]string{},
+		"Use a spinach YAML configuration file. Repeat to layer multiple files",
+	)
+	cmd.Flags().StringVarP(flags.Profile, "profile", "",
+		"",
+		fmt.Sprintf("Use a built-in spinach profile layered under spinach files (%s)", strings.Join(config.Profiles(), ", ")),
+	)
+}
+
 func initKubeConfigFlags() {
 	rootCmd.Flags().StringVar(
 		flags.KubeConfig,
@@ -336,11 +440,45 @@ func initFlags() {
 		"",
 		"Prometheus pushgateway auth password",
 	)
+	rootCmd.Flags().StringVar(
+		flags.OTLPEndpoint,
+		"otlp-endpoint",
+		"",
+		"OTLP/HTTP collector address e.g. http://localhost:4318. Defaults to $OTEL_EXPORTER_OTLP_ENDPOINT",
+	)
 }
 
 // ----------------------------------------------------------------------------
 // Helpers...
 
+// usedFlags returns the flags explicitly set on the command line.
+func usedFlags(cmd *cobra.Command) []string {
+	var ff []string
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		v := f.Value.String()
+		switch {
+		case slices.Contains(redactedFlags, f.Name):
+			v = "***"
+		case slices.Contains(urlFlags, f.Name):
+			v = redactURL(v)
+		}
+		ff = append(ff, "--"+f.Name+"="+v)
+	})
+
+	return ff
+}
+
+// redactURL strips credentials from a url.
+func redactURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		return "***"
+	}
+	u.User, u.RawQuery, u.Fragment = nil, "", ""
+
+	return u.String()
+}
+
 func clearScreen() {
 	if flags.ClearScreen == nil || !*flags.ClearScreen {
 		return
@@ -214,6 +214,44 @@ func (a *Aliases) Singular(gvr types.GVR) string {
 	return m.SingularName
 }
 
+// Kind returns the kind of a given resource.
+func (a *Aliases) Kind(gvr types.GVR) string {
+	return a.metas[gvr].Kind
+}
+
+// GVRFor returns the resource for a given api version and kind. The exact
+// version is preferred. Otherwise the linted version is used, falling back to
+// the first served version in lexical order.
+func (a *Aliases) GVRFor(apiVersion, kind string) (types.GVR, bool) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return types.BlankGVR, false
+	}
+	var gg []types.GVR
+	for gvr, m := range a.metas {
+		if m.Kind != kind || gvr.G() != gv.Group || strings.Contains(m.Name, "/") {
+			continue
+		}
+		if gvr.V() == gv.Version {
+			return gvr, true
+		}
+		gg = append(gg, gvr)
+	}
+	if len(gg) == 0 {
+		return types.BlankGVR, false
+	}
+	slices.SortFunc(gg, func(a, b types.GVR) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	for _, gvr := range gg {
+		if Glossary[R(gvr.R())].String() == gvr.String() {
+			return gvr, true
+		}
+	}
+
+	return gg[0], true
+}
+
 // Exclude checks if section should be excluded from the report.
 func (a *Aliases) Exclude(gvr types.GVR, sections []string) bool {
 	if len(sections) == 0 {
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAliasesGVRFor(t *testing.T) {
+	a := NewAliases()
+	a.metas = ResourceMetas{
+		types.NewGVR("apps/v1/deployments"):                  {Name: "deployments", Kind: "Deployment"},
+		types.NewGVR("apps/v1/deployments/status"):           {Name: "deployments/status", Kind: "Deployment"},
+		types.NewGVR("networking.k8s.io/v1/ingresses"):       {Name: "ingresses", Kind: "Ingress"},
+		types.NewGVR("networking.k8s.io/v1/networkpolicies"): {Name: "networkpolicies", Kind: "NetworkPolicy"},
+		types.NewGVR("fred.io/v1alpha1/blees"):               {Name: "blees", Kind: "Blee"},
+		types.NewGVR("zorg.io/v1/zorgs"):                     {Name: "zorgs", Kind: "Zorg"},
+		types.NewGVR("zorg.io/v1beta1/zorgs"):                {Name: "zorgs", Kind: "Zorg"},
+	}
+
+	uu := map[string]struct {
+		apiVersion, kind string
+		e                string
+		ok               bool
+	}{
+		"deployment": {
+			apiVersion: "apps/v1",
+			kind:       "Deployment",
+			e:          "apps/v1/deployments",
+			ok:         true,
+		},
+		"ingress": {
+			apiVersion: "networking.k8s.io/v1",
+			kind:       "Ingress",
+			e:          "networking.k8s.io/v1/ingresses",
+			ok:         true,
+		},
+		"network-policy": {
+			apiVersion: "networking.k8s.io/v1",
+			kind:       "NetworkPolicy",
+			e:          "networking.k8s.io/v1/networkpolicies",
+			ok:         true,
+		},
+		"crd": {
+			apiVersion: "fred.io/v1",
+			kind:       "Blee",
+			e:          "fred.io/v1alpha1/blees",
+			ok:         true,
+		},
+		"multi-exact": {
+			apiVersion: "zorg.io/v1beta1",
+			kind:       "Zorg",
+			e:          "zorg.io/v1beta1/zorgs",
+			ok:         true,
+		},
+		"multi-exact-v1": {
+			apiVersion: "zorg.io/v1",
+			kind:       "Zorg",
+			e:          "zorg.io/v1/zorgs",
+			ok:         true,
+		},
+		"multi-fallback": {
+			apiVersion: "zorg.io/v2",
+			kind:       "Zorg",
+			e:          "zorg.io/v1/zorgs",
+			ok:         true,
+		},
+		"wrong-group": {
+			apiVersion: "v1",
+			kind:       "Deployment",
+		},
+		"unknown": {
+			apiVersion: "apps/v1",
+			kind:       "Zorg",
+		},
+		"invalid": {
+			apiVersion: "a/b/c",
+			kind:       "Deployment",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			gvr, ok := a.GVRFor(u.apiVersion, u.kind)
+			assert.Equal(t, u.ok, ok)
+			if ok {
+				assert.Equal(t, u.e, gvr.String())
+			}
+		})
+	}
+}
+
+func TestAliasesGVRForPreferred(t *testing.T) {
+	a := NewAliases()
+	a.metas = ResourceMetas{
+		types.NewGVR("zorg.io/v1/zorgs"):      {Name: "zorgs", Kind: "Zorg"},
+		types.NewGVR("zorg.io/v1beta1/zorgs"): {Name: "zorgs", Kind: "Zorg"},
+	}
+	Glossary["zorgs"] = types.NewGVR("zorg.io/v1beta1/zorgs")
+	defer delete(Glossary, "zorgs")
+
+	gvr, ok := a.GVRFor("zorg.io/v2", "Zorg")
+	assert.True(t, ok)
+	assert.Equal(t, "zorg.io/v1beta1/zorgs", gvr.String())
+}
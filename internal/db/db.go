@@ -19,6 +19,9 @@ import (
 	mv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 )
 
+// maxOwnerDepth caps owner references traversal.
+const maxOwnerDepth = 5
+
 type DB struct {
 	*memdb.MemDB
 }
@@ -403,3 +406,83 @@ func (db *DB) Exists(kind types.GVR, fqn string) bool {
 
 	return err == nil && o != nil
 }
+
+// FindController returns the controller reference of a resource if any.
+func (db *DB) FindController(gvr types.GVR, fqn string) *metav1.OwnerReference {
+	txn := db.Txn(false)
+	defer txn.Abort()
+	ref, _ := controllerOf(txn, gvr, fqn)
+
+	return ref
+}
+
+// FindUID returns the uid of a resource if any.
+func (db *DB) FindUID(gvr types.GVR, fqn string) string {
+	txn := db.Txn(false)
+	defer txn.Abort()
+	o, err := txn.First(gvr.String(), "id", fqn)
+	if err != nil || o == nil {
+		return ""
+	}
+	m, ok := o.(metav1.Object)
+	if !ok {
+		return ""
+	}
+
+	return string(m.GetUID())
+}
+
+// FindLabels returns the labels of a resource if any.
+func (db *DB) FindLabels(gvr types.GVR, fqn string) map[string]string {
+	txn := db.Txn(false)
+	defer txn.Abort()
+	o, err := txn.First(gvr.String(), "id", fqn)
+	if err != nil || o == nil {
+		return nil
+	}
+	m, ok := o.(metav1.Object)
+	if !ok {
+		return nil
+	}
+
+	return m.GetLabels()
+}
+
+// FindOwner walks up controller references to locate the top level owner of a
+// resource. It returns the owner resource name and fqn or the given resource
+// when it is not controlled by anything.
+func (db *DB) FindOwner(aa *internal.Aliases, gvr types.GVR, fqn string) (string, string) {
+	txn := db.Txn(false)
+	defer txn.Abort()
+
+	r := gvr.R()
+	for range maxOwnerDepth {
+		ref, ns := controllerOf(txn, gvr, fqn)
+		if ref == nil {
+			break
+		}
+		ogvr, ok := aa.GVRFor(ref.APIVersion, ref.Kind)
+		if !ok {
+			break
+		}
+		r, fqn = ogvr.R(), client.FQN(ns, ref.Name)
+		if gvr = internal.Glossary[internal.R(r)]; gvr == types.BlankGVR {
+			break
+		}
+	}
+
+	return r, fqn
+}
+
+func controllerOf(txn *memdb.Txn, gvr types.GVR, fqn string) (*metav1.OwnerReference, string) {
+	o, err := txn.First(gvr.String(), "id", fqn)
+	if err != nil || o == nil {
+		return nil, ""
+	}
+	m, ok := o.(metav1.Object)
+	if !ok {
+		return nil, ""
+	}
+
+	return metav1.GetControllerOf(m), m.GetNamespace()
+}
@@ -9,12 +9,15 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/derailed/popeye/internal"
 	"github.com/derailed/popeye/internal/client"
 	"github.com/derailed/popeye/internal/dao"
 	"github.com/derailed/popeye/types"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -23,16 +26,23 @@ import (
 
 type CastFn[T any] func(o runtime.Object) (*T, error)
 
+// LoadStat tracks how long a resource took to load.
+type LoadStat struct {
+	GVR      types.GVR
+	Duration time.Duration
+	Count    int
+}
+
 type Loader struct {
 	DB     *DB
-	loaded map[types.GVR]struct{}
+	loaded map[types.GVR]LoadStat
 	mx     sync.RWMutex
 }
 
 func NewLoader(db *DB) *Loader {
 	l := Loader{
 		DB:     db,
-		loaded: make(map[types.GVR]struct{}),
+		loaded: make(map[types.GVR]LoadStat),
 	}
 
 	return &l
@@ -47,11 +57,24 @@ func (l *Loader) isLoaded(gvr types.GVR) bool {
 	return ok
 }
 
-func (l *Loader) setLoaded(gvr types.GVR) {
+func (l *Loader) setLoaded(gvr types.GVR, start time.Time, count int) {
 	l.mx.Lock()
 	defer l.mx.Unlock()
 
-	l.loaded[gvr] = struct{}{}
+	l.loaded[gvr] = LoadStat{GVR: gvr, Duration: time.Since(start), Count: count}
+}
+
+// Stats returns the loaded resources stats.
+func (l *Loader) Stats() []LoadStat {
+	l.mx.RLock()
+	defer l.mx.RUnlock()
+
+	ss := make([]LoadStat, 0, len(l.loaded))
+	for _, s := range l.loaded {
+		ss = append(ss, s)
+	}
+
+	return ss
 }
 
 // LoadResource loads resource and save to db.
@@ -59,14 +82,22 @@ func LoadResource[T metav1.ObjectMetaAccessor](ctx context.Context, l *Loader, g
 	if l.isLoaded(gvr) || gvr == types.BlankGVR {
 		return nil
 	}
+	ctx, span := internal.Tracer(ctx).Start(ctx, "load "+gvr.R(), trace.WithAttributes(attribute.String("popeye.gvr", gvr.String())))
+	defer span.End()
+
+	start := time.Now()
+
 	oo, err := loadResource(ctx, gvr)
 	if err != nil {
+		span.RecordError(err)
 		return err
 	}
+	span.SetAttributes(attribute.Int("popeye.resources", len(oo)))
 	if err = Save[T](ctx, l.DB, gvr, oo); err != nil {
+		span.RecordError(err)
 		return err
 	}
-	l.setLoaded(gvr)
+	l.setLoaded(gvr, start, len(oo))
 
 	return nil
 }
@@ -120,6 +151,7 @@ func (l *Loader) LoadPodMX(ctx context.Context) error {
 	c := mustExtractFactory(ctx).Client()
 
 	log.Debug().Msg("PRELOAD PMX")
+	start := time.Now()
 	ll, err := l.fetchPodsMetrics(c)
 	if err != nil {
 		return err
@@ -131,7 +163,7 @@ func (l *Loader) LoadPodMX(ctx context.Context) error {
 			return err
 		}
 	}
-	l.setLoaded(pmxGVR)
+	l.setLoaded(pmxGVR, start, len(ll.Items))
 
 	return nil
 }
@@ -147,6 +179,7 @@ func (l *Loader) LoadNodeMX(ctx context.Context) error {
 		return nil
 	}
 	log.Debug().Msg("PRELOAD NMX")
+	start := time.Now()
 	ll, err := l.fetchNodesMetrics(c)
 	if err != nil {
 		return err
@@ -158,7 +191,7 @@ func (l *Loader) LoadNodeMX(ctx context.Context) error {
 			return err
 		}
 	}
-	l.setLoaded(nmxGVR)
+	l.setLoaded(nmxGVR, start, len(ll.Items))
 
 	return nil
 }
@@ -202,6 +235,7 @@ func (l *Loader) LoadGeneric(ctx context.Context, gvr types.GVR) error {
 		return nil
 	}
 
+	start := time.Now()
 	oo, err := l.fetchGeneric(ctx, gvr)
 	if err != nil {
 		return err
@@ -213,7 +247,7 @@ func (l *Loader) LoadGeneric(ctx context.Context, gvr types.GVR) error {
 			return err
 		}
 	}
-	l.setLoaded(gvr)
+	l.setLoaded(gvr, start, len(oo))
 
 	return nil
 }
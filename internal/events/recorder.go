@@ -0,0 +1,261 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package events
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/derailed/popeye/types"
+	"github.com/rs/zerolog/log"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// Reason the reason of Popeye lint events.
+	Reason = "PopeyeLint"
+
+	// Component the source component of Popeye lint events.
+	Component = "popeye"
+
+	// Action the action of Popeye lint events.
+	Action = "Lint"
+
+	// DefaultQPS the default sustained number of event writes per second.
+	DefaultQPS = 5
+
+	// DefaultBurst the default maximum burst of event writes.
+	DefaultBurst = 25
+
+	// maxMessage caps the event message length.
+	maxMessage = 1024
+
+	// maxName caps the event name length.
+	maxName = 253
+)
+
+// UIDResolver resolves resource uids.
+type UIDResolver interface {
+	// UID returns the uid of a given resource if known.
+	UID(gvr, fqn string) string
+}
+
+// Object represents a resource with lint issues.
+type Object struct {
+	GVR       string
+	Kind      string
+	Namespace string
+	Name      string
+	Level     rules.Level
+	Messages  []string
+}
+
+// Recorder writes lint findings as Kubernetes events on offending resources.
+// Events are named after the resource and its findings so rescans bump the
+// existing event series count instead of creating new events.
+type Recorder struct {
+	clt      kubernetes.Interface
+	uids     UIDResolver
+	limiter  flowcontrol.RateLimiter
+	clock    clock.Clock
+	instance string
+}
+
+// NewRecorder returns a new event recorder.
+func NewRecorder(clt kubernetes.Interface, uids UIDResolver) *Recorder {
+	host, _ := os.Hostname()
+
+	return &Recorder{
+		clt:      clt,
+		uids:     uids,
+		limiter:  flowcontrol.NewTokenBucketRateLimiter(DefaultQPS, DefaultBurst),
+		clock:    clock.RealClock{},
+		instance: host,
+	}
+}
+
+// Record writes an event for each resource with issues in the scan and
+// returns the number of events written.
+func (r *Recorder) Record(ctx context.Context, s *report.Scan) (int, error) {
+	var (
+		count int
+		errs  error
+	)
+	for _, o := range Collect(s) {
+		if err := r.limiter.Wait(ctx); err != nil {
+			return count, errors.Join(errs, err)
+		}
+		if err := r.record(ctx, o); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("event %s/%s failed: %w", o.Namespace, o.Name, err))
+			continue
+		}
+		count++
+	}
+
+	return count, errs
+}
+
+func (r *Recorder) record(ctx context.Context, o Object) error {
+	ev := r.newEvent(o)
+	api := r.clt.CoreV1().Events(ev.Namespace)
+	curr, err := api.Get(ctx, ev.Name, metav1.GetOptions{})
+	switch {
+	case kerrors.IsNotFound(err):
+		_, err = api.Create(ctx, ev, metav1.CreateOptions{})
+		if !kerrors.IsAlreadyExists(err) {
+			return err
+		}
+		if curr, err = api.Get(ctx, ev.Name, metav1.GetOptions{}); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"count":         curr.Count + 1,
+		"lastTimestamp": ev.LastTimestamp,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = api.Patch(ctx, ev.Name, ktypes.MergePatchType, patch, metav1.PatchOptions{})
+
+	return err
+}
+
+func (r *Recorder) newEvent(o Object) *v1.Event {
+	ns := o.Namespace
+	if ns == "" {
+		ns = metav1.NamespaceDefault
+	}
+	gvr := types.NewGVR(o.GVR)
+	ref := v1.ObjectReference{
+		APIVersion: gvr.GV().String(),
+		Kind:       o.Kind,
+		Namespace:  o.Namespace,
+		Name:       o.Name,
+	}
+	if r.uids != nil {
+		ref.UID = ktypes.UID(r.uids.UID(o.GVR, client.FQN(o.Namespace, o.Name)))
+	}
+	msg := o.Message()
+	now := metav1.NewTime(r.clock.Now())
+
+	return &v1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      eventName(ref, msg),
+			Namespace: ns,
+		},
+		InvolvedObject:      ref,
+		Reason:              Reason,
+		Message:             msg,
+		Type:                o.Type(),
+		Source:              v1.EventSource{Component: Component, Host: r.instance},
+		FirstTimestamp:      now,
+		LastTimestamp:       now,
+		Count:               1,
+		Action:              Action,
+		ReportingController: Component,
+		ReportingInstance:   r.instance,
+	}
+}
+
+// Type returns the event type given the resource highest issue severity.
+func (o Object) Type() string {
+	if o.Level >= rules.WarnLevel {
+		return v1.EventTypeWarning
+	}
+
+	return v1.EventTypeNormal
+}
+
+// Message returns the event message listing all the resource issues.
+func (o Object) Message() string {
+	msg := strings.Join(o.Messages, "; ")
+	if len(msg) > maxMessage {
+		msg = msg[:maxMessage-3] + "..."
+	}
+
+	return msg
+}
+
+// Collect returns the resources with issues in the scan, sorted by namespace
+// and name. Issues at the ok level are ignored.
+func Collect(s *report.Scan) []Object {
+	mm := make(map[string]*Object)
+	for _, sec := range s.Sections {
+		for _, i := range sec.Issues {
+			if i.Level <= rules.OkLevel {
+				continue
+			}
+			key := i.GVR + "|" + client.FQN(i.Namespace, i.Name)
+			o, ok := mm[key]
+			if !ok {
+				o = &Object{GVR: i.GVR, Kind: i.Kind, Namespace: i.Namespace, Name: i.Name}
+				mm[key] = o
+			}
+			o.Level = max(o.Level, i.Level)
+			o.Messages = append(o.Messages, issueMessage(i))
+		}
+	}
+
+	oo := make([]Object, 0, len(mm))
+	for _, o := range mm {
+		if o.Kind == "" {
+			log.Debug().Msgf("Skipping events for %s %s. Unknown kind", o.GVR, o.Name)
+			continue
+		}
+		slices.Sort(o.Messages)
+		o.Messages = slices.Compact(o.Messages)
+		oo = append(oo, *o)
+	}
+	slices.SortFunc(oo, func(a, b Object) int {
+		return strings.Compare(a.GVR+"|"+a.Namespace+"/"+a.Name, b.GVR+"|"+b.Namespace+"/"+b.Name)
+	})
+
+	return oo
+}
+
+func issueMessage(i report.ScanIssue) string {
+	msg := i.Message
+	if i.Code != 0 {
+		msg = fmt.Sprintf("[POP-%d] %s", i.Code, msg)
+	}
+	if i.Container != "" {
+		msg = i.Container + ": " + msg
+	}
+
+	return msg
+}
+
+// eventName returns a stable event name for a resource findings.
+func eventName(ref v1.ObjectReference, msg string) string {
+	h := sha256.Sum256([]byte(strings.Join([]string{
+		ref.APIVersion, ref.Kind, ref.Namespace, ref.Name, string(ref.UID), Reason, msg,
+	}, "|")))
+	suffix := ".popeye-" + hex.EncodeToString(h[:])[:12]
+	name := strings.ToLower(ref.Name)
+	if len(name)+len(suffix) > maxName {
+		name = name[:maxName-len(suffix)]
+	}
+
+	return name + suffix
+}
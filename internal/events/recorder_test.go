@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package events
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/util/flowcontrol"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+type uids map[string]string
+
+func (u uids) UID(gvr, fqn string) string {
+	return u[gvr+"|"+fqn]
+}
+
+func TestCollect(t *testing.T) {
+	oo := Collect(makeScan())
+
+	assert.Equal(t, []Object{
+		{
+			GVR:       "apps/v1/deployments",
+			Kind:      "Deployment",
+			Namespace: "ns1",
+			Name:      "d1",
+			Level:     rules.InfoLevel,
+			Messages:  []string{"[POP-404] Deprecated API"},
+		},
+		{
+			GVR:      "v1/nodes",
+			Kind:     "Node",
+			Name:     "n1",
+			Level:    rules.ErrorLevel,
+			Messages: []string{"[POP-707] No node metrics available"},
+		},
+		{
+			GVR:       "v1/pods",
+			Kind:      "Pod",
+			Namespace: "ns1",
+			Name:      "p1",
+			Level:     rules.WarnLevel,
+			Messages: []string{
+				"[POP-206] No PodDisruptionBudget defined",
+				"c1: [POP-106] No resources requests/limits defined",
+			},
+		},
+	}, oo)
+}
+
+func TestObjectMessage(t *testing.T) {
+	o := Object{Messages: []string{strings.Repeat("a", 600), strings.Repeat("b", 600)}}
+	msg := o.Message()
+
+	assert.Len(t, msg, maxMessage)
+	assert.True(t, strings.HasSuffix(msg, "..."))
+}
+
+func TestRecord(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		clt  = fake.NewSimpleClientset()
+		at   = time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+		clk  = clocktesting.NewFakeClock(at)
+		rec  = newTestRecorder(clt, clk)
+		scan = makeScan()
+	)
+
+	n, err := rec.Record(ctx, scan)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	clk.Step(time.Hour)
+	n, err = rec.Record(ctx, scan)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	ee, err := clt.CoreV1().Events("ns1").List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, ee.Items, 2)
+	for _, e := range ee.Items {
+		assert.Equal(t, int32(2), e.Count)
+		assert.Equal(t, at, e.FirstTimestamp.UTC())
+		assert.Equal(t, at.Add(time.Hour), e.LastTimestamp.UTC())
+		assert.Equal(t, Reason, e.Reason)
+		assert.Equal(t, Component, e.Source.Component)
+	}
+
+	pod := findEvent(ee.Items, "p1")
+	assert.Equal(t, v1.EventTypeWarning, pod.Type)
+	assert.Equal(t, v1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Namespace:  "ns1",
+		Name:       "p1",
+		UID:        "uid-p1",
+	}, pod.InvolvedObject)
+	assert.Equal(t, "[POP-206] No PodDisruptionBudget defined; c1: [POP-106] No resources requests/limits defined", pod.Message)
+
+	dp := findEvent(ee.Items, "d1")
+	assert.Equal(t, v1.EventTypeNormal, dp.Type)
+	assert.Equal(t, "apps/v1", dp.InvolvedObject.APIVersion)
+
+	ee, err = clt.CoreV1().Events(metav1.NamespaceDefault).List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, ee.Items, 1)
+	assert.Equal(t, "Node", ee.Items[0].InvolvedObject.Kind)
+	assert.Empty(t, ee.Items[0].InvolvedObject.Namespace)
+	assert.Equal(t, v1.EventTypeWarning, ee.Items[0].Type)
+}
+
+func TestRecordNewFindings(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		clt  = fake.NewSimpleClientset()
+		rec  = newTestRecorder(clt, clocktesting.NewFakeClock(time.Now()))
+		scan = makeScan()
+	)
+
+	_, err := rec.Record(ctx, scan)
+	assert.NoError(t, err)
+	scan.Sections[0].Issues = scan.Sections[0].Issues[1:]
+	_, err = rec.Record(ctx, scan)
+	assert.NoError(t, err)
+
+	ee, err := clt.CoreV1().Events("ns1").List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, ee.Items, 3)
+}
+
+func TestRecordRateLimited(t *testing.T) {
+	var (
+		clt         = fake.NewSimpleClientset()
+		rec         = newTestRecorder(clt, clocktesting.NewFakeClock(time.Now()))
+		ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	)
+	defer cancel()
+	rec.limiter = flowcontrol.NewTokenBucketRateLimiter(0.001, 1)
+
+	n, err := rec.Record(ctx, makeScan())
+	assert.Error(t, err)
+	assert.Equal(t, 1, n)
+}
+
+// Helpers...
+
+func newTestRecorder(clt *fake.Clientset, clk *clocktesting.FakeClock) *Recorder {
+	return &Recorder{
+		clt: clt,
+		uids: uids{
+			"v1/pods|ns1/p1":             "uid-p1",
+			"apps/v1/deployments|ns1/d1": "uid-d1",
+			"v1/nodes|n1":                "uid-n1",
+		},
+		limiter:  flowcontrol.NewFakeAlwaysRateLimiter(),
+		clock:    clk,
+		instance: "fred",
+	}
+}
+
+func findEvent(ee []v1.Event, name string) v1.Event {
+	for _, e := range ee {
+		if e.InvolvedObject.Name == name {
+			return e
+		}
+	}
+
+	return v1.Event{}
+}
+
+func makeScan() *report.Scan {
+	return &report.Scan{
+		Sections: []report.ScanSection{
+			{
+				Linter: "pods",
+				GVR:    "v1/pods",
+				Issues: []report.ScanIssue{
+					{Code: 106, Level: rules.WarnLevel, Message: "No resources requests/limits defined", GVR: "v1/pods", Kind: "Pod", Namespace: "ns1", Name: "p1", Container: "c1"},
+					{Code: 206, Level: rules.WarnLevel, Message: "No PodDisruptionBudget defined", GVR: "v1/pods", Kind: "Pod", Namespace: "ns1", Name: "p1"},
+					{Level: rules.OkLevel, Message: "All good", GVR: "v1/pods", Kind: "Pod", Namespace: "ns1", Name: "p2"},
+				},
+			},
+			{
+				Linter: "deployments",
+				GVR:    "apps/v1/deployments",
+				Issues: []report.ScanIssue{
+					{Code: 404, Level: rules.InfoLevel, Message: "Deprecated API", GVR: "apps/v1/deployments", Kind: "Deployment", Namespace: "ns1", Name: "d1"},
+				},
+			},
+			{
+				Linter: "nodes",
+				GVR:    "v1/nodes",
+				Issues: []report.ScanIssue{
+					{Code: 707, Level: rules.ErrorLevel, Message: "No node metrics available", GVR: "v1/nodes", Kind: "Node", Name: "n1"},
+				},
+			},
+		},
+	}
+}
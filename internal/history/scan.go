@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package history
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/derailed/popeye/internal/report"
+)
+
+// Scan represents a recorded scan.
+type Scan struct {
+	Timestamp    time.Time `json:"timestamp"`
+	Cluster      string    `json:"cluster"`
+	Context      string    `json:"context"`
+	Score        int       `json:"score"`
+	Grade        string    `json:"grade"`
+	Sections     []Section `json:"sections"`
+	Fingerprints []string  `json:"fingerprints,omitempty"`
+}
+
+// Section represents a recorded linter section tally.
+type Section struct {
+	Linter  string `json:"linter"`
+	GVR     string `json:"gvr"`
+	Score   int    `json:"score"`
+	OK      int    `json:"ok"`
+	Info    int    `json:"info"`
+	Warning int    `json:"warning"`
+	Error   int    `json:"error"`
+}
+
+// NewScan returns a scan record for the given report.
+func NewScan(b *report.Builder, cluster, context string, at time.Time) Scan {
+	score, _ := b.ToScore()
+	s := Scan{
+		Timestamp: at.UTC(),
+		Cluster:   cluster,
+		Context:   context,
+		Score:     score,
+		Grade:     b.Report.Grade,
+		Sections:  make([]Section, 0, len(b.Report.Sections)),
+	}
+	for _, section := range b.Report.Sections {
+		if section.Tally == nil || !section.Tally.IsValid() {
+			continue
+		}
+		s.Sections = append(s.Sections, Section{
+			Linter:  section.Title,
+			GVR:     section.GVR,
+			Score:   section.Tally.Score(),
+			OK:      section.Tally.OkCount(),
+			Info:    section.Tally.InfoCount(),
+			Warning: section.Tally.WarnCount(),
+			Error:   section.Tally.ErrCount(),
+		})
+		for fqn, ii := range section.Outcome {
+			for _, i := range ii {
+				s.Fingerprints = append(s.Fingerprints, i.Fingerprint(section.GVR, fqn))
+			}
+		}
+	}
+	slices.SortFunc(s.Sections, func(a, b Section) int {
+		return strings.Compare(a.Linter, b.Linter)
+	})
+	slices.Sort(s.Fingerprints)
+	s.Fingerprints = slices.Compact(s.Fingerprints)
+
+	return s
+}
+
+// Section returns the named linter section if any.
+func (s Scan) Section(linter string) (Section, bool) {
+	idx, ok := slices.BinarySearchFunc(s.Sections, linter, func(a Section, l string) int {
+		return strings.Compare(a.Linter, l)
+	})
+	if !ok {
+		return Section{}, false
+	}
+
+	return s.Sections[idx], true
+}
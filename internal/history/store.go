@@ -0,0 +1,256 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package history
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// FileName represents the history store file name.
+	FileName = "history.db"
+
+	keySep      = "\x1f"
+	openTimeout = 5 * time.Second
+)
+
+var (
+	// ErrNoHistory indicates no scans were recorded.
+	ErrNoHistory = errors.New("no scan history")
+
+	scansBucket    = []byte("scans")
+	findingsBucket = []byte("findings")
+)
+
+// Target represents a scanned cluster context.
+type Target struct {
+	Cluster string `json:"cluster"`
+	Context string `json:"context"`
+}
+
+func (t Target) key() []byte {
+	return []byte(t.Cluster + keySep + t.Context)
+}
+
+func targetFromKey(k []byte) Target {
+	cl, ct, _ := strings.Cut(string(k), keySep)
+
+	return Target{Cluster: cl, Context: ct}
+}
+
+// Store tracks scans history.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens or creates a history store at the given path.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("unable to open history store %q: %w", path, err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the store.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Record records a new scan.
+func (s *Store) Record(sc Scan) error {
+	raw, err := json.Marshal(sc)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		root, err := tx.CreateBucketIfNotExists(scansBucket)
+		if err != nil {
+			return err
+		}
+		b, err := root.CreateBucketIfNotExists(Target{Cluster: sc.Cluster, Context: sc.Context}.key())
+		if err != nil {
+			return err
+		}
+
+		return b.Put(timeKey(sc.Timestamp), raw)
+	})
+}
+
+// Targets returns all recorded cluster contexts.
+func (s *Store) Targets() ([]Target, error) {
+	var tt []Target
+	err := s.db.View(func(tx *bolt.Tx) error {
+		root := tx.Bucket(scansBucket)
+		if root == nil {
+			return nil
+		}
+		return root.ForEachBucket(func(k []byte) error {
+			tt = append(tt, targetFromKey(k))
+			return nil
+		})
+	})
+
+	return tt, err
+}
+
+// Observe records the given section issue fingerprints keyed by scanned
+// resource and returns when each was first seen. Fingerprints no longer
+// reported on a scanned resource are forgotten so resolved issues start afresh
+// should they come back. Resources outside the scan are left alone unless all
+// is set, in which case the scan covered the whole section and resources no
+// longer around are forgotten too.
+func (s *Store) Observe(t Target, section string, ff map[string][]string, all bool, now time.Time) (map[string]time.Time, error) {
+	seen := make(map[string]time.Time)
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		root, err := tx.CreateBucketIfNotExists(findingsBucket)
+		if err != nil {
+			return err
+		}
+		tb, err := root.CreateBucketIfNotExists(t.key())
+		if err != nil {
+			return err
+		}
+		sb, err := tb.CreateBucketIfNotExists([]byte(section))
+		if err != nil {
+			return err
+		}
+		if all {
+			if err := forget(sb, ff); err != nil {
+				return err
+			}
+		}
+		for fqn, fps := range ff {
+			if err := observe(sb, fqn, fps, now, seen); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return seen, err
+}
+
+// observe records a resource fingerprints and collects when each was first seen.
+func observe(sb *bolt.Bucket, fqn string, ff []string, now time.Time, seen map[string]time.Time) error {
+	if b := sb.Bucket([]byte(fqn)); b != nil {
+		for _, f := range ff {
+			v := b.Get([]byte(f))
+			if v == nil {
+				continue
+			}
+			var at time.Time
+			if err := at.UnmarshalText(v); err != nil {
+				return err
+			}
+			seen[f] = at
+		}
+		if err := sb.DeleteBucket([]byte(fqn)); err != nil {
+			return err
+		}
+	}
+	if len(ff) == 0 {
+		return nil
+	}
+	b, err := sb.CreateBucket([]byte(fqn))
+	if err != nil {
+		return err
+	}
+	for _, f := range ff {
+		if _, ok := seen[f]; !ok {
+			seen[f] = now.UTC()
+		}
+		raw, err := seen[f].MarshalText()
+		if err != nil {
+			return err
+		}
+		if err := b.Put([]byte(f), raw); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// forget drops the resources that are not part of the given fingerprints.
+func forget(sb *bolt.Bucket, ff map[string][]string) error {
+	var stale [][]byte
+	err := sb.ForEachBucket(func(k []byte) error {
+		if _, ok := ff[string(k)]; !ok {
+			stale = append(stale, k)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, k := range stale {
+		if err := sb.DeleteBucket(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Scans returns at most the last max scans for a given cluster context,
+// oldest first. A max of 0 returns all scans.
+func (s *Store) Scans(t Target, max int) ([]Scan, error) {
+	var ss []Scan
+	err := s.db.View(func(tx *bolt.Tx) error {
+		root := tx.Bucket(scansBucket)
+		if root == nil {
+			return nil
+		}
+		b := root.Bucket(t.key())
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			if max > 0 && len(ss) == max {
+				break
+			}
+			var sc Scan
+			if err := json.Unmarshal(v, &sc); err != nil {
+				return err
+			}
+			ss = append(ss, sc)
+		}
+		return nil
+	})
+	slices.Reverse(ss)
+
+	return ss, err
+}
+
+// Last returns the most recent scan for a given cluster context.
+func (s *Store) Last(t Target) (Scan, error) {
+	ss, err := s.Scans(t, 1)
+	if err != nil {
+		return Scan{}, err
+	}
+	if len(ss) == 0 {
+		return Scan{}, ErrNoHistory
+	}
+
+	return ss[0], nil
+}
+
+func timeKey(t time.Time) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, uint64(t.UnixNano()))
+
+	return k
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package history_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/derailed/popeye/internal/history"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	s, err := history.Open(filepath.Join(t.TempDir(), history.FileName))
+	require.NoError(t, err)
+	defer s.Close()
+
+	t1, t2 := history.Target{Cluster: "c1", Context: "ctx1"}, history.Target{Cluster: "arn:aws:eks:cluster/c2", Context: "ctx2"}
+	_, err = s.Last(t1)
+	assert.ErrorIs(t, err, history.ErrNoHistory)
+
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, score := range []int{50, 60, 70} {
+		assert.NoError(t, s.Record(history.Scan{Timestamp: at.Add(time.Duration(i) * time.Hour), Cluster: t1.Cluster, Context: t1.Context, Score: score}))
+	}
+	assert.NoError(t, s.Record(history.Scan{Timestamp: at, Cluster: t2.Cluster, Context: t2.Context, Score: 10}))
+
+	tt, err := s.Targets()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []history.Target{t1, t2}, tt)
+
+	last, err := s.Last(t1)
+	assert.NoError(t, err)
+	assert.Equal(t, 70, last.Score)
+
+	uu := map[string]struct {
+		max int
+		e   []int
+	}{
+		"all":   {e: []int{50, 60, 70}},
+		"last2": {max: 2, e: []int{60, 70}},
+		"over":  {max: 10, e: []int{50, 60, 70}},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			ss, err := s.Scans(t1, u.max)
+			assert.NoError(t, err)
+			scores := make([]int, 0, len(ss))
+			for _, sc := range ss {
+				scores = append(scores, sc.Score)
+			}
+			assert.Equal(t, u.e, scores)
+		})
+	}
+}
+
+func TestStoreObserve(t *testing.T) {
+	s, err := history.Open(filepath.Join(t.TempDir(), history.FileName))
+	require.NoError(t, err)
+	defer s.Close()
+
+	tg := history.Target{Cluster: "c1", Context: "ctx1"}
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2, t3 := t1.Add(24*time.Hour), t1.Add(48*time.Hour)
+
+	seen, err := s.Observe(tg, "v1/pods", map[string][]string{"ns1/p1": {"f1", "f2"}}, true, t1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{"f1": t1, "f2": t1}, seen)
+
+	seen, err = s.Observe(tg, "v1/pods", map[string][]string{"ns1/p1": {"f1", "f3"}}, true, t2)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{"f1": t1, "f3": t2}, seen)
+
+	seen, err = s.Observe(tg, "v1/nodes", map[string][]string{"n1": {"f1"}}, true, t2)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{"f1": t2}, seen)
+
+	seen, err = s.Observe(tg, "v1/pods", map[string][]string{"ns1/p1": {"f1", "f2"}}, true, t3)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{"f1": t1, "f2": t3}, seen)
+}
+
+func TestStoreObserveScoped(t *testing.T) {
+	s, err := history.Open(filepath.Join(t.TempDir(), history.FileName))
+	require.NoError(t, err)
+	defer s.Close()
+
+	tg := history.Target{Cluster: "c1", Context: "ctx1"}
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2, t3, t4 := t1.Add(24*time.Hour), t1.Add(48*time.Hour), t1.Add(72*time.Hour)
+
+	seen, err := s.Observe(tg, "v1/pods", map[string][]string{
+		"ns1/p1": {"f1"},
+		"ns2/p2": {"f2"},
+		"ns2/p3": {"f3"},
+	}, true, t1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{"f1": t1, "f2": t1, "f3": t1}, seen)
+
+	// A scan scoped to ns1 leaves ns2 resources alone.
+	seen, err = s.Observe(tg, "v1/pods", map[string][]string{"ns1/p1": {}}, false, t2)
+	assert.NoError(t, err)
+	assert.Empty(t, seen)
+
+	seen, err = s.Observe(tg, "v1/pods", map[string][]string{
+		"ns1/p1": {"f1"},
+		"ns2/p2": {"f2"},
+	}, true, t3)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{"f1": t3, "f2": t1}, seen)
+
+	// A full scan forgets resources no longer around.
+	seen, err = s.Observe(tg, "v1/pods", map[string][]string{"ns2/p3": {"f3"}}, true, t4)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{"f3": t4}, seen)
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package history
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Mover tracks a linter score change between two scans.
+type Mover struct {
+	Linter string `json:"linter"`
+	From   int    `json:"from"`
+	To     int    `json:"to"`
+	Delta  int    `json:"delta"`
+}
+
+// Movers returns at most max linters whose score changed the most between
+// two scans. Linters missing from either scan are ignored.
+func Movers(from, to Scan, max int) []Mover {
+	mm := make([]Mover, 0, len(to.Sections))
+	for _, s := range to.Sections {
+		prev, ok := from.Section(s.Linter)
+		if !ok || prev.Score == s.Score {
+			continue
+		}
+		mm = append(mm, Mover{
+			Linter: s.Linter,
+			From:   prev.Score,
+			To:     s.Score,
+			Delta:  s.Score - prev.Score,
+		})
+	}
+	slices.SortFunc(mm, func(a, b Mover) int {
+		if c := cmp.Compare(abs(b.Delta), abs(a.Delta)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Linter, b.Linter)
+	})
+	if max > 0 && len(mm) > max {
+		mm = mm[:max]
+	}
+
+	return mm
+}
+
+// Churn returns the number of issues introduced and fixed between two scans.
+func Churn(from, to Scan) (added, fixed int) {
+	for _, f := range to.Fingerprints {
+		if _, ok := slices.BinarySearch(from.Fingerprints, f); !ok {
+			added++
+		}
+	}
+	for _, f := range from.Fingerprints {
+		if _, ok := slices.BinarySearch(to.Fingerprints, f); !ok {
+			fixed++
+		}
+	}
+
+	return
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package history_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/popeye/internal/history"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/derailed/popeye/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovers(t *testing.T) {
+	from := history.Scan{Sections: []history.Section{
+		{Linter: "nodes", Score: 100},
+		{Linter: "pods", Score: 50},
+		{Linter: "secrets", Score: 80},
+		{Linter: "services", Score: 90},
+	}}
+	to := history.Scan{Sections: []history.Section{
+		{Linter: "cronjobs", Score: 10},
+		{Linter: "nodes", Score: 100},
+		{Linter: "pods", Score: 70},
+		{Linter: "secrets", Score: 60},
+		{Linter: "services", Score: 80},
+	}}
+
+	uu := map[string]struct {
+		max int
+		e   []history.Mover
+	}{
+		"all": {
+			e: []history.Mover{
+				{Linter: "pods", From: 50, To: 70, Delta: 20},
+				{Linter: "secrets", From: 80, To: 60, Delta: -20},
+				{Linter: "services", From: 90, To: 80, Delta: -10},
+			},
+		},
+		"top": {
+			max: 1,
+			e: []history.Mover{
+				{Linter: "pods", From: 50, To: 70, Delta: 20},
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, history.Movers(from, to, u.max))
+		})
+	}
+}
+
+func TestNewScanChurn(t *testing.T) {
+	gvr := types.NewGVR("v1/pods")
+	scan := func(o issues.Outcome) history.Scan {
+		b := report.NewBuilder()
+		b.AddSection(gvr, "pod", rules.OkLevel, o, report.NewTally().Rollup(o))
+		return history.NewScan(b, "c1", "ctx1", time.Now())
+	}
+	s1 := scan(issues.Outcome{
+		"default/p1": issues.Issues{issues.New(gvr, issues.Root, rules.ErrorLevel, "[POP-100] boom")},
+		"default/p2": issues.Issues{issues.New(gvr, issues.Root, rules.WarnLevel, "[POP-101] blee")},
+	})
+	s2 := scan(issues.Outcome{
+		"default/p1": issues.Issues{issues.New(gvr, issues.Root, rules.ErrorLevel, "[POP-100] boom")},
+		"default/p2": issues.Issues{},
+		"default/p3": issues.Issues{issues.New(gvr, issues.Root, rules.WarnLevel, "[POP-101] blee")},
+	})
+
+	assert.Equal(t, 0, s1.Score)
+	assert.Equal(t, []history.Section{{Linter: "pods", GVR: "v1/pods", Score: 33, OK: 1, Warning: 1, Error: 1}}, s2.Sections)
+	added, fixed := history.Churn(s1, s2)
+	assert.Equal(t, 1, added)
+	assert.Equal(t, 1, fixed)
+}
@@ -5,6 +5,10 @@ package issues
 
 import (
 	_ "embed"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/derailed/popeye/internal/rules"
 	"gopkg.in/yaml.v2"
@@ -44,6 +48,35 @@ func (c *Codes) Refine(oo rules.Overrides) {
 	}
 }
 
+// Filter returns the sorted code ids matching the given linter and severity.
+// An empty linter or an OkLevel severity matches all codes.
+func (c *Codes) Filter(linter string, level rules.Level) []rules.ID {
+	ids := make([]rules.ID, 0, len(c.Glossary))
+	for id, code := range c.Glossary {
+		if level != rules.OkLevel && code.Severity != level {
+			continue
+		}
+		if linter != "" && len(code.Linters) > 0 && !slices.Contains(code.Linters, linter) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	return ids
+}
+
+// ParseID converts a code designation ie POP-100 or 100 to a code id.
+func ParseID(s string) (rules.ID, error) {
+	n := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "POP-")
+	id, err := strconv.Atoi(n)
+	if err != nil || id <= 0 {
+		return rules.ZeroCode, fmt.Errorf("invalid code %q. Expecting POP-xxx or xxx", s)
+	}
+
+	return rules.ID(id), nil
+}
+
 // Helpers...
 
 func validSeverity(l rules.Level) bool {
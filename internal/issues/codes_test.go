@@ -48,3 +48,77 @@ func TestRefine(t *testing.T) {
 	assert.Equal(t, rules.InfoLevel, cc.Glossary[100].Severity)
 	assert.Equal(t, rules.WarnLevel, cc.Glossary[101].Severity)
 }
+
+func TestCodesDocumented(t *testing.T) {
+	cc, err := issues.LoadCodes()
+	assert.Nil(t, err)
+
+	for id, c := range cc.Glossary {
+		assert.NotEmpty(t, c.Description, "POP-%d", id)
+		assert.NotEmpty(t, c.Remediation, "POP-%d", id)
+		if id != 666 {
+			assert.NotEmpty(t, c.Linters, "POP-%d", id)
+		}
+	}
+}
+
+func TestCodesFilter(t *testing.T) {
+	cc, err := issues.LoadCodes()
+	assert.Nil(t, err)
+
+	uu := map[string]struct {
+		linter string
+		level  rules.Level
+		e      []rules.ID
+	}{
+		"linter": {
+			linter: "poddisruptionbudgets",
+			e:      []rules.ID{666, 900, 901},
+		},
+		"linter-level": {
+			linter: "nodes",
+			level:  rules.ErrorLevel,
+			e:      []rules.ID{666, 702, 703, 706, 707},
+		},
+		"level": {
+			linter: "persistentvolumes",
+			level:  rules.WarnLevel,
+			e:      []rules.ID{1001},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, cc.Filter(u.linter, u.level))
+		})
+	}
+	assert.Equal(t, 117, len(cc.Filter("", rules.OkLevel)))
+}
+
+func TestParseID(t *testing.T) {
+	uu := map[string]struct {
+		s   string
+		e   rules.ID
+		err bool
+	}{
+		"plain":  {s: "1204", e: 1204},
+		"prefix": {s: "POP-1204", e: 1204},
+		"lower":  {s: "pop-100", e: 100},
+		"toast":  {s: "POP-fred", err: true},
+		"zero":   {s: "0", err: true},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			id, err := issues.ParseID(u.s)
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, u.e, id)
+		})
+	}
+}
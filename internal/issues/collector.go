@@ -6,6 +6,7 @@ package issues
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/derailed/popeye/internal"
 	"github.com/derailed/popeye/internal/rules"
@@ -20,12 +21,13 @@ type Collector struct {
 	*config.Config
 
 	outcomes Outcome
+	skipped  map[string]struct{}
 	codes    *Codes
 }
 
 // NewCollector returns a new issue collector.
 func NewCollector(codes *Codes, cfg *config.Config) *Collector {
-	return &Collector{Config: cfg, outcomes: Outcome{}, codes: codes}
+	return &Collector{Config: cfg, outcomes: Outcome{}, skipped: make(map[string]struct{}), codes: codes}
 }
 
 // Outcome returns scan outcome.
@@ -38,12 +40,25 @@ func (c *Collector) InitOutcome(fqn string) {
 	c.outcomes[fqn] = Issues{}
 }
 
+// CloseOutcome drops excluded resources without issues from the outcome.
 func (c *Collector) CloseOutcome(ctx context.Context, fqn string, cos []string) {
 	if c.NoConcerns(fqn) && c.Config.ExcludeFQN(internal.MustExtractSectionGVR(ctx), fqn, cos) {
 		c.ClearOutcome(fqn)
+		c.skipped[fqn] = struct{}{}
 	}
 }
 
+// Skipped returns the sorted resources dropped from the outcome due to exclusions.
+func (c *Collector) Skipped() []string {
+	ss := make([]string, 0, len(c.skipped))
+	for fqn := range c.skipped {
+		ss = append(ss, fqn)
+	}
+	slices.SortFunc(ss, SortKeys)
+
+	return ss
+}
+
 // ClearOutcome delete all fqn related issues.
 func (c *Collector) ClearOutcome(fqn string) {
 	delete(c.outcomes, fqn)
@@ -66,13 +81,13 @@ func (c *Collector) AddSubCode(ctx context.Context, code rules.ID, args ...inter
 	if !ok {
 		log.Error().Err(fmt.Errorf("No code with ID %d", code)).Msg("AddSubCode failed")
 	}
-	if co.Severity < rules.Level(c.Config.LintLevel) {
+	if co.Severity < c.Config.LintLevelFor(run.SectionGVR.R()) {
 		return
 	}
 
 	run.Spec.GVR, run.Spec.Code = run.SectionGVR, code
 	if !c.Match(run.Spec) {
-		c.addIssue(run.Spec.FQN, New(run.GroupGVR, run.Group, co.Severity, co.Format(code, args...)))
+		c.addIssue(run.Spec.FQN, New(run.GroupGVR, run.Group, co.Severity, co.Format(code, args...)).WithCode(code, args...))
 	}
 }
 
@@ -84,13 +99,13 @@ func (c *Collector) AddCode(ctx context.Context, code rules.ID, args ...interfac
 		// BOZO!! refact once codes are in!!
 		panic(fmt.Errorf("no codes found with id %d", code))
 	}
-	if co.Severity < rules.Level(c.Config.LintLevel) {
+	if co.Severity < c.Config.LintLevelFor(run.SectionGVR.R()) {
 		return
 	}
 
 	run.Spec.GVR, run.Spec.Code = run.SectionGVR, code
 	if !c.Match(run.Spec) {
-		c.addIssue(run.Spec.FQN, New(run.SectionGVR, Root, co.Severity, co.Format(code, args...)))
+		c.addIssue(run.Spec.FQN, New(run.SectionGVR, Root, co.Severity, co.Format(code, args...)).WithCode(code, args...))
 	}
 }
 
@@ -110,7 +125,7 @@ func (c *Collector) AddErr(ctx context.Context, errs ...error) {
 		panic(fmt.Errorf("no codes found with id %d", errCode))
 	}
 	for _, e := range errs {
-		c.addIssue(run.Spec.FQN, New(run.SectionGVR, Root, rules.ErrorLevel, co.Format(errCode, e.Error())))
+		c.addIssue(run.Spec.FQN, New(run.SectionGVR, Root, rules.ErrorLevel, co.Format(errCode, e.Error())).WithCode(errCode, e.Error()))
 	}
 }
 
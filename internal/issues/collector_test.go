@@ -243,8 +243,80 @@ func TestAddSubCode(t *testing.T) {
 	}
 }
 
+func TestAddCodeLintLevels(t *testing.T) {
+	uu := map[string]struct {
+		gvr   types.GVR
+		code  rules.ID
+		count int
+	}{
+		"linter-below": {
+			gvr:  types.NewGVR("v1/pods"),
+			code: 101,
+		},
+		"linter-above": {
+			gvr:   types.NewGVR("v1/pods"),
+			code:  100,
+			count: 1,
+		},
+		"global": {
+			gvr:   types.NewGVR("v1/services"),
+			code:  101,
+			count: 1,
+		},
+	}
+
+	f := config.NewFlags()
+	level := "warn,pods=error"
+	f.LintLevel = &level
+	cfg, err := config.NewConfig(f)
+	assert.NoError(t, err)
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			c := NewCollector(loadCodes(t), cfg)
+			ctx := context.WithValue(context.Background(), internal.KeyRunInfo, internal.RunInfo{
+				SectionGVR: u.gvr,
+				Spec:       rules.Spec{FQN: "ns1/fred"},
+			})
+			c.AddCode(ctx, u.code)
+
+			assert.Equal(t, u.count, len(c.Outcome()["ns1/fred"]))
+		})
+	}
+}
+
+func TestCloseOutcome(t *testing.T) {
+	f := config.NewFlags()
+	sp := "testdata/sp-skip.yml"
+	f.Spinach = &sp
+	cfg, err := config.NewConfig(f)
+	assert.NoError(t, err)
+
+	c := NewCollector(loadCodes(t), cfg)
+	ctx := context.WithValue(context.Background(), internal.KeyRunInfo, internal.RunInfo{
+		SectionGVR: types.NewGVR("v1/pods"),
+	})
+	for _, fqn := range []string{"kube-system/p2", "kube-system/p1", "ns1/p1"} {
+		c.InitOutcome(fqn)
+		c.CloseOutcome(ctx, fqn, nil)
+	}
+
+	assert.Equal(t, []string{"ns1/p1"}, keys(c.Outcome()))
+	assert.Equal(t, []string{"kube-system/p1", "kube-system/p2"}, c.Skipped())
+}
+
 // Helpers...
 
+func keys(o Outcome) []string {
+	kk := make([]string, 0, len(o))
+	for k := range o {
+		kk = append(kk, k)
+	}
+
+	return kk
+}
+
 func makeContext(section, fqn, group string) context.Context {
 	return context.WithValue(context.Background(), internal.KeyRunInfo, internal.RunInfo{
 		Section: section,
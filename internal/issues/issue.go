@@ -4,8 +4,13 @@
 package issues
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/derailed/popeye/internal/rules"
 	"github.com/derailed/popeye/types"
@@ -22,6 +27,15 @@ type Issue struct {
 	GVR     string      `yaml:"gvr" json:"gvr"`
 	Level   rules.Level `yaml:"level" json:"level"`
 	Message string      `yaml:"message" json:"message"`
+
+	// ID tracks the issue code if any.
+	ID rules.ID `yaml:"-" json:"-"`
+
+	// Args tracks the raw arguments used to format the code message.
+	Args []string `yaml:"-" json:"-"`
+
+	// FirstSeen tracks when the issue was first observed if known.
+	FirstSeen *time.Time `yaml:"first_seen,omitempty" json:"first_seen,omitempty"`
 }
 
 // New returns a new lint issue.
@@ -34,7 +48,22 @@ func Newf(gvr types.GVR, group string, level rules.Level, format string, args ..
 	return New(gvr, group, level, fmt.Sprintf(format, args...))
 }
 
+// WithCode tags the issue with its code and raw message arguments.
+func (i Issue) WithCode(id rules.ID, args ...any) Issue {
+	i.ID = id
+	for _, a := range args {
+		i.Args = append(i.Args, fmt.Sprintf("%v", a))
+	}
+
+	return i
+}
+
+// Code returns the issue code. Issues not tagged with a code fall back to the
+// code found in the message prefix if any.
 func (i Issue) Code() (string, bool) {
+	if i.ID != 0 {
+		return strconv.Itoa(int(i.ID)), true
+	}
 	mm := codeRX.FindStringSubmatch(i.Message)
 	if len(mm) < 2 {
 		return "", false
@@ -43,6 +72,41 @@ func (i Issue) Code() (string, bool) {
 	return mm[1], true
 }
 
+// Text returns the issue message without its code prefix.
+func (i Issue) Text() string {
+	loc := codeRX.FindStringIndex(i.Message)
+	if loc == nil {
+		return i.Message
+	}
+
+	return strings.TrimSpace(i.Message[loc[1]:])
+}
+
+// Fingerprint returns a stable identifier for the issue reported on the given
+// section resource. Issues without a code are identified by their message.
+func (i Issue) Fingerprint(section, fqn string) string {
+	id, ok := i.Code()
+	if !ok {
+		id = i.Message
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{section, fqn, id, i.Group}, "|")))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// OpenDays returns the number of days the issue has been open for.
+func (i Issue) OpenDays(now time.Time) (int, bool) {
+	if i.FirstSeen == nil {
+		return 0, false
+	}
+	d := now.Sub(*i.FirstSeen)
+	if d < 0 {
+		return 0, true
+	}
+
+	return int(d.Hours() / 24), true
+}
+
 // Dump for debugging.
 func (i Issue) Dump() {
 	fmt.Printf("  %s (%d) %s\n", i.GVR, i.Level, i.Message)
@@ -50,7 +114,7 @@ func (i Issue) Dump() {
 
 // Blank checks if an issue is blank.
 func (i Issue) Blank() bool {
-	return i == Blank
+	return i.Group == "" && i.GVR == "" && i.Level == rules.OkLevel && i.Message == "" && i.ID == 0 && len(i.Args) == 0 && i.FirstSeen == nil
 }
 
 // IsSubIssue checks if error is a sub error.
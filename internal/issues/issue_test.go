@@ -37,6 +37,7 @@ func TestBlank(t *testing.T) {
 	}{
 		"blank":    {Issue{}, true},
 		"notBlank": {New(types.NewGVR("fred"), Root, rules.WarnLevel, "blah"), false},
+		"code":     {Issue{}.WithCode(100), false},
 	}
 
 	for k := range uu {
@@ -46,3 +47,83 @@ func TestBlank(t *testing.T) {
 		})
 	}
 }
+
+func TestText(t *testing.T) {
+	uu := map[string]struct {
+		i Issue
+		e string
+	}{
+		"plain": {New(types.NewGVR("fred"), Root, rules.WarnLevel, "blah"), "blah"},
+		"code":  {New(types.NewGVR("fred"), Root, rules.WarnLevel, "[POP-100] blah"), "blah"},
+		"inner": {New(types.NewGVR("fred"), Root, rules.WarnLevel, "blah [POP-100]"), "blah [POP-100]"},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.i.Text())
+		})
+	}
+}
+
+func TestCode(t *testing.T) {
+	gvr := types.NewGVR("fred")
+	uu := map[string]struct {
+		i    Issue
+		code string
+		ok   bool
+		args []string
+	}{
+		"plain": {i: New(gvr, Root, rules.WarnLevel, "blah")},
+		"message": {
+			i:    New(gvr, Root, rules.WarnLevel, "[POP-100] blah"),
+			code: "100",
+			ok:   true,
+		},
+		"tagged": {
+			i:    New(gvr, Root, rules.WarnLevel, "[POP-100] blah 10 bozo").WithCode(101, 10, "bozo"),
+			code: "101",
+			ok:   true,
+			args: []string{"10", "bozo"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			code, ok := u.i.Code()
+			assert.Equal(t, u.ok, ok)
+			assert.Equal(t, u.code, code)
+			assert.Equal(t, u.args, u.i.Args)
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	gvr := types.NewGVR("v1/pods")
+	i := New(gvr, "c1", rules.WarnLevel, "[POP-100] blah")
+	fp := i.Fingerprint("v1/pods", "default/p1")
+
+	uu := map[string]struct {
+		i       Issue
+		section string
+		fqn     string
+		e       bool
+	}{
+		"same":      {i, "v1/pods", "default/p1", true},
+		"message":   {New(gvr, "c1", rules.ErrorLevel, "[POP-100] blee"), "v1/pods", "default/p1", true},
+		"section":   {i, "apps/v1/deployments", "default/p1", false},
+		"fqn":       {i, "v1/pods", "default/p2", false},
+		"container": {New(gvr, "c2", rules.WarnLevel, "[POP-100] blah"), "v1/pods", "default/p1", false},
+		"code":      {New(gvr, "c1", rules.WarnLevel, "[POP-101] blah"), "v1/pods", "default/p1", false},
+		"no-code":   {New(gvr, "c1", rules.WarnLevel, "blah"), "v1/pods", "default/p1", false},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Len(t, u.i.Fingerprint(u.section, u.fqn), 64)
+			assert.Equal(t, u.e, fp == u.i.Fingerprint(u.section, u.fqn))
+		})
+	}
+}
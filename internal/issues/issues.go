@@ -9,6 +9,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/derailed/popeye/internal/client"
 	"github.com/derailed/popeye/internal/issues/tally"
@@ -193,6 +194,50 @@ func (o Outcome) Filter(level rules.Level) Outcome {
 	return o
 }
 
+// Fingerprints returns the fingerprints of the issues reported on the given
+// section keyed by resource. Resources without issues map to no fingerprints.
+func (o Outcome) Fingerprints(section string) map[string][]string {
+	ff := make(map[string][]string, len(o))
+	for fqn, ii := range o {
+		ff[fqn] = make([]string, 0, len(ii))
+		for _, i := range ii {
+			ff[fqn] = append(ff[fqn], i.Fingerprint(section, fqn))
+		}
+	}
+
+	return ff
+}
+
+// Age stamps the section issues with the time they were first seen.
+func (o Outcome) Age(section string, seen map[string]time.Time) {
+	for fqn, ii := range o {
+		for idx, i := range ii {
+			if t, ok := seen[i.Fingerprint(section, fqn)]; ok {
+				ii[idx].FirstSeen = &t
+			}
+		}
+	}
+}
+
+// FilterAge filters out issues first seen after the given time. Resources
+// left without issues are dropped so they do not count as passing.
+func (o Outcome) FilterAge(since time.Time) Outcome {
+	for k, issues := range o {
+		vv := make(Issues, 0, len(issues))
+		for _, issue := range issues {
+			if issue.FirstSeen == nil || !issue.FirstSeen.After(since) {
+				vv = append(vv, issue)
+			}
+		}
+		if len(vv) == 0 && len(issues) > 0 {
+			delete(o, k)
+			continue
+		}
+		o[k] = vv
+	}
+	return o
+}
+
 func (o Outcome) Dump() {
 	if len(o) == 0 {
 		fmt.Println("No ISSUES!")
@@ -5,6 +5,7 @@ package issues
 
 import (
 	"testing"
+	"time"
 
 	"github.com/derailed/popeye/internal/rules"
 	"github.com/derailed/popeye/types"
@@ -57,3 +58,46 @@ func TestGroup(t *testing.T) {
 	assert.Equal(t, rules.ErrorLevel, o["s2"].MaxSeverity())
 	assert.Equal(t, 2, len(grp))
 }
+
+func TestOutcomeAge(t *testing.T) {
+	gvr := types.NewGVR("v1/pods")
+	o := Outcome{
+		"default/p1": Issues{
+			New(gvr, Root, rules.ErrorLevel, "[POP-100] boom"),
+			New(gvr, "c1", rules.WarnLevel, "[POP-101] blee"),
+		},
+		"default/p2": Issues{
+			New(gvr, Root, rules.InfoLevel, "blah"),
+		},
+		"default/p3": Issues{
+			New(gvr, Root, rules.WarnLevel, "[POP-102] fred"),
+		},
+		"default/p4": Issues{},
+	}
+	ff := o.Fingerprints("v1/pods")
+	assert.Equal(t, 4, len(ff))
+	assert.Equal(t, 2, len(ff["default/p1"]))
+	assert.Empty(t, ff["default/p4"])
+
+	old, recent := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)
+	o.Age("v1/pods", map[string]time.Time{
+		o["default/p1"][0].Fingerprint("v1/pods", "default/p1"): old,
+		o["default/p1"][1].Fingerprint("v1/pods", "default/p1"): recent,
+		o["default/p3"][0].Fingerprint("v1/pods", "default/p3"): recent,
+	})
+	assert.Equal(t, old, *o["default/p1"][0].FirstSeen)
+	assert.Equal(t, recent, *o["default/p1"][1].FirstSeen)
+	assert.Nil(t, o["default/p2"][0].FirstSeen)
+
+	days, ok := o["default/p1"][0].OpenDays(recent)
+	assert.True(t, ok)
+	assert.Equal(t, 8, days)
+	_, ok = o["default/p2"][0].OpenDays(recent)
+	assert.False(t, ok)
+
+	o.FilterAge(recent.Add(-7 * 24 * time.Hour))
+	assert.Equal(t, Issues{o["default/p1"][0]}, o["default/p1"])
+	assert.Equal(t, 1, len(o["default/p2"]))
+	assert.NotContains(t, o, "default/p3")
+	assert.Contains(t, o, "default/p4")
+}
@@ -18,4 +18,5 @@ const (
 	KeyVersion       ContextKey = "version"
 	KeyDB            ContextKey = "db"
 	KeyNamespaceName ContextKey = "namespaceName"
+	KeyTracer        ContextKey = "tracer"
 )
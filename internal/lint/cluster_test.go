@@ -31,6 +31,7 @@ func TestClusterLint(t *testing.T) {
 						GVR:     "clusters",
 						Group:   issues.Root,
 						Message: "[POP-406] K8s version OK",
+						ID:      406,
 						Level:   rules.OkLevel,
 					},
 				},
@@ -45,6 +46,7 @@ func TestClusterLint(t *testing.T) {
 						GVR:     "clusters",
 						Group:   issues.Root,
 						Message: "[POP-406] K8s version OK",
+						ID:      406,
 						Level:   rules.OkLevel,
 					},
 				},
@@ -59,6 +61,7 @@ func TestClusterLint(t *testing.T) {
 						GVR:     "clusters",
 						Group:   issues.Root,
 						Message: "[POP-405] Is this a jurassic cluster? Might want to upgrade K8s a bit",
+						ID:      405,
 						Level:   rules.WarnLevel,
 					},
 				},
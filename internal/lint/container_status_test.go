@@ -38,7 +38,7 @@ func TestContainerStatusLint(t *testing.T) {
 				State:        v1.ContainerState{},
 			},
 			1,
-			issues.New(types.NewGVR("containers"), "c1", rules.ErrorLevel, "[POP-204] Pod is not ready [0/1]"),
+			issues.New(types.NewGVR("containers"), "c1", rules.ErrorLevel, "[POP-204] Pod is not ready [0/1]").WithCode(204, 0, 1),
 		},
 		"waitingNoReason": {
 			v1.ContainerStatus{
@@ -50,7 +50,7 @@ func TestContainerStatusLint(t *testing.T) {
 				},
 			},
 			1,
-			issues.New(types.NewGVR("containers"), "c1", rules.ErrorLevel, "[POP-203] Pod is waiting [0/1] blah"),
+			issues.New(types.NewGVR("containers"), "c1", rules.ErrorLevel, "[POP-203] Pod is waiting [0/1] blah").WithCode(203, 0, 1, "blah"),
 		},
 		"waiting": {
 			v1.ContainerStatus{
@@ -62,7 +62,7 @@ func TestContainerStatusLint(t *testing.T) {
 				},
 			},
 			1,
-			issues.New(types.NewGVR("containers"), "c1", rules.ErrorLevel, "[POP-202] Pod is waiting [0/1]"),
+			issues.New(types.NewGVR("containers"), "c1", rules.ErrorLevel, "[POP-202] Pod is waiting [0/1]").WithCode(202, 0, 1),
 		},
 		"terminatedReason": {
 			v1.ContainerStatus{
@@ -74,7 +74,7 @@ func TestContainerStatusLint(t *testing.T) {
 				},
 			},
 			1,
-			issues.New(types.NewGVR("containers"), "c1", rules.WarnLevel, "[POP-201] Pod is terminating [1/1] blah"),
+			issues.New(types.NewGVR("containers"), "c1", rules.WarnLevel, "[POP-201] Pod is terminating [1/1] blah").WithCode(201, 1, 1, "blah"),
 		},
 		"terminated": {
 			v1.ContainerStatus{
@@ -86,7 +86,7 @@ func TestContainerStatusLint(t *testing.T) {
 				},
 			},
 			1,
-			issues.New(types.NewGVR("containers"), "c1", rules.WarnLevel, "[POP-200] Pod is terminating [1/1]"),
+			issues.New(types.NewGVR("containers"), "c1", rules.WarnLevel, "[POP-200] Pod is terminating [1/1]").WithCode(200, 1, 1),
 		},
 		"terminatedNotReady": {
 			v1.ContainerStatus{
@@ -107,7 +107,7 @@ func TestContainerStatusLint(t *testing.T) {
 				RestartCount: 11,
 			},
 			1,
-			issues.New(types.NewGVR("containers"), "c1", rules.WarnLevel, "[POP-205] Pod was restarted (11) times"),
+			issues.New(types.NewGVR("containers"), "c1", rules.WarnLevel, "[POP-205] Pod was restarted (11) times").WithCode(205, 11, "times"),
 		},
 	}
 
@@ -69,6 +69,7 @@ func Test_svcCheckEndpoints(t *testing.T) {
 					GVR:     "v1/services",
 					Level:   rules.ErrorLevel,
 					Message: "[POP-1105] No associated endpoints found",
+					ID:      1105,
 				},
 			},
 		},
@@ -84,6 +85,7 @@ func Test_svcCheckEndpoints(t *testing.T) {
 					GVR:     "v1/services",
 					Level:   rules.ErrorLevel,
 					Message: "[POP-1105] No associated endpoints found",
+					ID:      1105,
 				},
 			},
 		},
@@ -96,6 +98,7 @@ func Test_svcCheckEndpoints(t *testing.T) {
 					GVR:     "v1/services",
 					Level:   rules.WarnLevel,
 					Message: "[POP-1109] Single endpoint is associated with this service",
+					ID:      1109,
 				},
 			},
 		},
@@ -108,6 +111,7 @@ func Test_svcCheckEndpoints(t *testing.T) {
 					GVR:     "v1/services",
 					Level:   rules.WarnLevel,
 					Message: "[POP-1110] Match EP has no subsets",
+					ID:      1110,
 				},
 			},
 		},
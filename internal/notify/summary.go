@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package notify
+
+import (
+	"slices"
+	"time"
+
+	"github.com/derailed/popeye/internal/history"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/internal/rules"
+)
+
+// Summary represents a scan summary fed to webhook payloads.
+type Summary struct {
+	Cluster     string           `json:"cluster"`
+	Context     string           `json:"context"`
+	ReportTime  string           `json:"report_time"`
+	Score       int              `json:"score"`
+	Grade       string           `json:"grade"`
+	Previous    *int             `json:"previous_score,omitempty"`
+	Delta       int              `json:"delta"`
+	Errors      int              `json:"errors"`
+	Warnings    int              `json:"warnings"`
+	NewIssues   int              `json:"new_issues"`
+	NewErrors   int              `json:"new_errors"`
+	FixedIssues int              `json:"fixed_issues"`
+	Sections    []SectionSummary `json:"sections"`
+}
+
+// SectionSummary represents a linter section summary.
+type SectionSummary struct {
+	Linter   string `json:"linter"`
+	Score    int    `json:"score"`
+	Errors   int    `json:"errors"`
+	Warnings int    `json:"warnings"`
+}
+
+// NewSummary returns a scan summary. Issue churn is only reported when a
+// baseline scan is given.
+func NewSummary(b *report.Builder, baseline *history.Scan) Summary {
+	score, _ := b.ToScore()
+	s := Summary{
+		Cluster:    b.ClusterName,
+		Context:    b.ContextName,
+		ReportTime: b.Report.Timestamp,
+		Score:      score,
+		Grade:      b.Report.Grade,
+		Sections:   make([]SectionSummary, 0, len(b.Report.Sections)),
+	}
+	for _, section := range b.Report.Sections {
+		if section.Tally == nil {
+			continue
+		}
+		s.Errors += section.Tally.ErrCount()
+		s.Warnings += section.Tally.WarnCount()
+		s.Sections = append(s.Sections, SectionSummary{
+			Linter:   section.Title,
+			Score:    section.Tally.Score(),
+			Errors:   section.Tally.ErrCount(),
+			Warnings: section.Tally.WarnCount(),
+		})
+	}
+	if baseline == nil {
+		return s
+	}
+
+	s.Previous, s.Delta = &baseline.Score, score-baseline.Score
+	current := history.NewScan(b, s.Cluster, s.Context, time.Now())
+	s.NewIssues, s.FixedIssues = history.Churn(*baseline, current)
+	for _, section := range b.Report.Sections {
+		for fqn, ii := range section.Outcome {
+			for _, i := range ii {
+				if i.Level < rules.ErrorLevel {
+					continue
+				}
+				if _, ok := slices.BinarySearch(baseline.Fingerprints, i.Fingerprint(section.GVR, fqn)); !ok {
+					s.NewErrors++
+				}
+			}
+		}
+	}
+
+	return s
+}
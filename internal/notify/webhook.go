@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"text/template"
+	"time"
+
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/rs/zerolog/log"
+)
+
+const slackTemplate = `{"text": {{ json (printf "Popeye scan %s (%s): grade %s (%d)%s. %d errors, %d warnings, %d new issues, %d fixed." .Cluster .Context .Grade .Score (delta .) .Errors .Warnings .NewIssues .FixedIssues) }}}`
+
+const teamsTemplate = `{
+  "@type": "MessageCard",
+  "@context": "http://schema.org/extensions",
+  "summary": {{ json (printf "Popeye scan %s grade %s (%d)" .Cluster .Grade .Score) }},
+  "title": {{ json (printf "Popeye scan %s (%s)" .Cluster .Context) }},
+  "text": {{ json (printf "Grade **%s** (%d)%s" .Grade .Score (delta .)) }},
+  "sections": [{"facts": [
+    {"name": "Errors", "value": "{{ .Errors }}"},
+    {"name": "Warnings", "value": "{{ .Warnings }}"},
+    {"name": "New issues", "value": "{{ .NewIssues }}"},
+    {"name": "New errors", "value": "{{ .NewErrors }}"},
+    {"name": "Fixed issues", "value": "{{ .FixedIssues }}"}
+  ]}]
+}`
+
+var funcs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		raw, err := json.Marshal(v)
+		return string(raw), err
+	},
+	"delta": func(s Summary) string {
+		if s.Previous == nil {
+			return ""
+		}
+		return fmt.Sprintf(" %+d vs last scan", s.Delta)
+	},
+}
+
+// Notify posts the scan summary to all webhooks whose conditions are met.
+func Notify(ctx context.Context, hooks config.Webhooks, s Summary) error {
+	var errs error
+	for _, w := range hooks {
+		if !ShouldNotify(w, s) {
+			log.Debug().Msgf("Skipping webhook %q. Conditions not met", w.Name)
+			continue
+		}
+		body, err := Render(w, s)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("webhook %q payload render failed: %w", w.Name, err))
+			continue
+		}
+		if err := post(ctx, w, body); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("webhook %q failed: %w", w.Name, err))
+		}
+	}
+
+	return errs
+}
+
+// ShouldNotify checks if any of the webhook conditions is met. Conditions
+// comparing against a prior scan are never met without one.
+func ShouldNotify(w config.Webhook, s Summary) bool {
+	for _, on := range w.Conditions() {
+		switch on {
+		case config.OnAlways:
+			return true
+		case config.OnScoreDrop:
+			if s.Previous != nil && s.Delta < 0 {
+				return true
+			}
+		case config.OnNewIssues:
+			if s.NewIssues > 0 {
+				return true
+			}
+		case config.OnNewErrors:
+			if s.NewErrors > 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Render renders the webhook payload.
+func Render(w config.Webhook, s Summary) ([]byte, error) {
+	tpl := w.Template
+	if tpl == "" {
+		switch w.PayloadKind() {
+		case config.WebhookSlack:
+			tpl = slackTemplate
+		case config.WebhookTeams:
+			tpl = teamsTemplate
+		default:
+			return json.Marshal(s)
+		}
+	}
+	t, err := template.New(w.Name).Funcs(funcs).Parse(tpl)
+	if err != nil {
+		return nil, err
+	}
+	var buff bytes.Buffer
+	if err := t.Execute(&buff, s); err != nil {
+		return nil, err
+	}
+
+	return buff.Bytes(), nil
+}
+
+func post(ctx context.Context, w config.Webhook, body []byte) error {
+	var (
+		backoff = w.BackoffDuration()
+		err     error
+	)
+	for attempt := 0; attempt <= w.MaxRetries(); attempt++ {
+		if attempt > 0 {
+			log.Debug().Msgf("Retrying webhook %q in %v", w.Name, backoff)
+			select {
+			case <-ctx.Done():
+				return errors.Join(err, ctx.Err())
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+		var retry bool
+		if retry, err = send(ctx, w, body); err == nil || !retry {
+			return err
+		}
+	}
+
+	return err
+}
+
+// send posts the payload once and reports whether a failure is worth retrying.
+func send(ctx context.Context, w config.Webhook, body []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.TimeoutDuration())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.Endpoint(), bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.Header() {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("unexpected status %s", resp.Status)
+	default:
+		return false, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+}
@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/derailed/popeye/internal/history"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/notify"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/derailed/popeye/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldNotify(t *testing.T) {
+	prev := 80
+	uu := map[string]struct {
+		on []string
+		s  notify.Summary
+		e  bool
+	}{
+		"default": {
+			e: true,
+		},
+		"drop-no-baseline": {
+			on: []string{config.OnScoreDrop},
+			s:  notify.Summary{Score: 10},
+		},
+		"drop": {
+			on: []string{config.OnScoreDrop},
+			s:  notify.Summary{Score: 70, Previous: &prev, Delta: -10},
+			e:  true,
+		},
+		"raise": {
+			on: []string{config.OnScoreDrop},
+			s:  notify.Summary{Score: 90, Previous: &prev, Delta: 10},
+		},
+		"new-errors": {
+			on: []string{config.OnScoreDrop, config.OnNewErrors},
+			s:  notify.Summary{Previous: &prev, NewIssues: 2, NewErrors: 1},
+			e:  true,
+		},
+		"new-warnings": {
+			on: []string{config.OnNewErrors},
+			s:  notify.Summary{Previous: &prev, NewIssues: 2},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, notify.ShouldNotify(config.Webhook{Name: "w", On: u.on}, u.s))
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	prev := 80
+	s := notify.Summary{Cluster: "c1", Context: "ctx1", Score: 70, Grade: "C", Previous: &prev, Delta: -10, Errors: 2, Warnings: 1, NewIssues: 3, FixedIssues: 1}
+	uu := map[string]struct {
+		w config.Webhook
+		e string
+	}{
+		"json": {
+			w: config.Webhook{Name: "w"},
+			e: `{"cluster":"c1","context":"ctx1","report_time":"","score":70,"grade":"C","previous_score":80,"delta":-10,"errors":2,"warnings":1,"new_issues":3,"new_errors":0,"fixed_issues":1,"sections":null}`,
+		},
+		"slack": {
+			w: config.Webhook{Name: "w", Kind: config.WebhookSlack},
+			e: `{"text": "Popeye scan c1 (ctx1): grade C (70) -10 vs last scan. 2 errors, 1 warnings, 3 new issues, 1 fixed."}`,
+		},
+		"custom": {
+			w: config.Webhook{Name: "w", Template: `{"msg": {{ json .Cluster }}, "score": {{ .Score }}}`},
+			e: `{"msg": "c1", "score": 70}`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			raw, err := notify.Render(u.w, s)
+			assert.NoError(t, err)
+			assert.Equal(t, u.e, string(raw))
+		})
+	}
+
+	raw, err := notify.Render(config.Webhook{Name: "w", Kind: config.WebhookTeams}, s)
+	assert.NoError(t, err)
+	var card map[string]any
+	assert.NoError(t, json.Unmarshal(raw, &card))
+	assert.Equal(t, "MessageCard", card["@type"])
+}
+
+func TestNotify(t *testing.T) {
+	uu := map[string]struct {
+		codes []int
+		calls int32
+		err   string
+	}{
+		"ok": {
+			codes: []int{http.StatusOK},
+			calls: 1,
+		},
+		"retry": {
+			codes: []int{http.StatusBadGateway, http.StatusTooManyRequests, http.StatusNoContent},
+			calls: 3,
+		},
+		"exhausted": {
+			codes: []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			calls: 3,
+			err:   `webhook "w" failed: unexpected status 500 Internal Server Error`,
+		},
+		"no-retry": {
+			codes: []int{http.StatusBadRequest},
+			calls: 1,
+			err:   `webhook "w" failed: unexpected status 400 Bad Request`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			var calls int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&calls, 1)
+				assert.Equal(t, "Bearer fred", r.Header.Get("Authorization"))
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				raw, _ := io.ReadAll(r.Body)
+				assert.Contains(t, string(raw), `"cluster":"c1"`)
+				w.WriteHeader(u.codes[n-1])
+			}))
+			defer srv.Close()
+
+			t.Setenv("POPEYE_TEST_TOKEN", "fred")
+			retries := 2
+			hooks := config.Webhooks{{
+				Name:    "w",
+				URL:     srv.URL,
+				Headers: map[string]string{"Authorization": "Bearer ${POPEYE_TEST_TOKEN}"},
+				Retries: &retries,
+				Backoff: "1ms",
+			}}
+			err := notify.Notify(context.Background(), hooks, notify.Summary{Cluster: "c1"})
+			if u.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, u.err)
+			}
+			assert.Equal(t, u.calls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestNewSummary(t *testing.T) {
+	gvr := types.NewGVR("v1/pods")
+	build := func(o issues.Outcome) *report.Builder {
+		b := report.NewBuilder()
+		b.AddSection(gvr, "pod", rules.OkLevel, o, report.NewTally().Rollup(o))
+		b.ClusterName, b.ContextName = "c1", "ctx1"
+		return b
+	}
+	baseline := history.NewScan(build(issues.Outcome{
+		"default/p1": issues.Issues{issues.New(gvr, issues.Root, rules.WarnLevel, "[POP-101] blee")},
+		"default/p2": issues.Issues{issues.New(gvr, issues.Root, rules.ErrorLevel, "[POP-100] boom")},
+	}), "c1", "ctx1", time.Now())
+
+	b := build(issues.Outcome{
+		"default/p1": issues.Issues{
+			issues.New(gvr, issues.Root, rules.WarnLevel, "[POP-101] blee"),
+			issues.New(gvr, issues.Root, rules.ErrorLevel, "[POP-100] boom"),
+		},
+		"default/p2": issues.Issues{},
+		"default/p3": issues.Issues{issues.New(gvr, issues.Root, rules.WarnLevel, "[POP-101] blee")},
+	})
+
+	s := notify.NewSummary(b, nil)
+	assert.Nil(t, s.Previous)
+	assert.Equal(t, 0, s.NewIssues)
+	assert.Equal(t, 33, s.Score)
+	assert.Equal(t, 1, s.Errors)
+	assert.Equal(t, 1, s.Warnings)
+
+	s = notify.NewSummary(b, &baseline)
+	assert.Equal(t, 0, *s.Previous)
+	assert.Equal(t, 33, s.Delta)
+	assert.Equal(t, 2, s.NewIssues)
+	assert.Equal(t, 1, s.NewErrors)
+	assert.Equal(t, 1, s.FixedIssues)
+}
@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+)
+
+// Scanner runs a scan against a namespace.
+type Scanner interface {
+	// Scan scans the given namespace. An empty namespace scans the cluster.
+	// The scan result is returned along with any report output errors.
+	Scan(ctx context.Context, s *PopeyeScan, ns string) (*NamespaceResult, error)
+}
+
+// Controller reconciles PopeyeScans by running scans on schedule and
+// reporting their outcome in the scan status.
+type Controller struct {
+	dial     dynamic.Interface
+	scanner  Scanner
+	adminNS  string
+	informer cache.SharedIndexInformer
+	queue    workqueue.TypedRateLimitingInterface[string]
+	clock    clock.Clock
+}
+
+// NewController returns a new controller. Scans living in the admin namespace
+// may target any namespace.
+func NewController(dial dynamic.Interface, s Scanner, adminNS string, resync time.Duration) *Controller {
+	c := Controller{
+		dial:     dial,
+		scanner:  s,
+		adminNS:  adminNS,
+		informer: dynamicinformer.NewDynamicSharedInformerFactory(dial, resync).ForResource(GVR).Informer(),
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: "popeyescans"},
+		),
+		clock: clock.RealClock{},
+	}
+	_, _ = c.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueue,
+		UpdateFunc: func(_, o any) { c.enqueue(o) },
+	})
+
+	return &c
+}
+
+// Run processes scans until the context is canceled. Scans run one at a time.
+func (c *Controller) Run(ctx context.Context) error {
+	defer c.queue.ShutDown()
+
+	go c.informer.Run(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), c.informer.HasSynced) {
+		return errors.New("popeyescans cache sync failed")
+	}
+	log.Info().Msgf("Reconciling %s", GVR.GroupResource())
+	go func() {
+		<-ctx.Done()
+		c.queue.ShutDown()
+	}()
+	for c.processNext(ctx) {
+	}
+
+	return nil
+}
+
+func (c *Controller) enqueue(o any) {
+	key, err := cache.MetaNamespaceKeyFunc(o)
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to enqueue scan")
+		return
+	}
+	c.queue.Add(key)
+}
+
+func (c *Controller) processNext(ctx context.Context) bool {
+	key, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	after, err := c.reconcile(ctx, key)
+	if err != nil {
+		log.Error().Err(err).Msgf("Reconcile %s failed", key)
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	if after > 0 {
+		c.queue.AddAfter(key, after)
+	}
+
+	return true
+}
+
+// reconcile runs the scan when due and returns when it should be checked again.
+func (c *Controller) reconcile(ctx context.Context, key string) (time.Duration, error) {
+	o, ok, err := c.informer.GetIndexer().GetByKey(key)
+	if err != nil || !ok {
+		return 0, err
+	}
+	u, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return 0, fmt.Errorf("unexpected object %T", o)
+	}
+	var s PopeyeScan
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &s); err != nil {
+		return 0, err
+	}
+
+	now := c.clock.Now()
+	next, err := nextScan(&s, now)
+	if err != nil {
+		st := s.Status
+		st.ObservedGeneration, st.NextScanTime, st.Message = s.Generation, nil, err.Error()
+		return 0, c.patchStatus(ctx, &s, st)
+	}
+	if next.IsZero() {
+		return 0, nil
+	}
+	if next.After(now) {
+		return next.Sub(now), nil
+	}
+
+	st := c.scan(ctx, &s)
+	if sched, _ := parseSchedule(s.Spec.Schedule); sched != nil {
+		t := metav1.NewTime(sched.Next(st.LastScanTime.Time))
+		st.NextScanTime = &t
+	}
+	if err := c.patchStatus(ctx, &s, st); err != nil {
+		return 0, err
+	}
+	if st.NextScanTime == nil {
+		return 0, nil
+	}
+
+	return st.NextScanTime.Sub(c.clock.Now()), nil
+}
+
+// scan scans all requested namespaces. The scan score and grade are the
+// ones of the lowest scoring namespace.
+func (c *Controller) scan(ctx context.Context, s *PopeyeScan) ScanStatus {
+	at := metav1.NewTime(c.clock.Now())
+	st := ScanStatus{
+		ObservedGeneration: s.Generation,
+		LastScanTime:       &at,
+		Results:            make([]NamespaceResult, 0),
+	}
+	nn, err := scanNamespaces(s, c.adminNS)
+	if err != nil {
+		st.Message = err.Error()
+		return st
+	}
+
+	var errs error
+	for _, ns := range nn {
+		target := ns
+		if ns == AllNamespaces {
+			target = ""
+		}
+		log.Info().Msgf("Scanning %s/%s namespace %q", s.Namespace, s.Name, ns)
+		res, err := c.scanner.Scan(ctx, s, target)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("namespace %q: %w", ns, err))
+		}
+		// Output failures still yield a scan result.
+		if res == nil {
+			continue
+		}
+		res.Namespace = ns
+		st.Results = append(st.Results, *res)
+	}
+	if errs != nil {
+		st.Message = errs.Error()
+	}
+	for i, r := range st.Results {
+		if i == 0 || r.Score < st.Score {
+			st.Score, st.Grade = r.Score, r.Grade
+		}
+		st.Counts.Add(r.Counts)
+		if st.LastReport == "" && len(r.Reports) > 0 {
+			st.LastReport = r.Reports[0]
+		}
+	}
+
+	return st
+}
+
+func (c *Controller) patchStatus(ctx context.Context, s *PopeyeScan, st ScanStatus) error {
+	raw, err := json.Marshal(map[string]any{"status": st})
+	if err != nil {
+		return err
+	}
+	_, err = c.dial.Resource(GVR).Namespace(s.Namespace).Patch(
+		ctx,
+		s.Name,
+		ktypes.MergePatchType,
+		raw,
+		metav1.PatchOptions{FieldManager: Component},
+		"status",
+	)
+
+	return err
+}
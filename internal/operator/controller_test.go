@@ -0,0 +1,245 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package operator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+type scanOutcome struct {
+	res *NamespaceResult
+	err error
+}
+
+type scanner map[string]scanOutcome
+
+func (s scanner) Scan(_ context.Context, _ *PopeyeScan, ns string) (*NamespaceResult, error) {
+	o, ok := s[ns]
+	if !ok {
+		return nil, errors.New("boom")
+	}
+	r := *o.res
+
+	return &r, o.err
+}
+
+func TestReconcile(t *testing.T) {
+	now := time.Date(2024, 3, 1, 10, 30, 0, 0, time.Local)
+	last := metav1.NewTime(time.Date(2024, 3, 1, 6, 0, 0, 0, time.Local))
+
+	uu := map[string]struct {
+		ns      string
+		spec    ScanSpec
+		status  ScanStatus
+		scanner Scanner
+		after   time.Duration
+		e       *ScanStatus
+	}{
+		"scan": {
+			ns:    "popeye",
+			spec:  ScanSpec{Schedule: "0 */6 * * *", Namespaces: []string{"ns1", "ns2"}},
+			after: 90 * time.Minute,
+			e: &ScanStatus{
+				ObservedGeneration: 1,
+				LastScanTime:       timePtr(now),
+				NextScanTime:       timePtr(time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local)),
+				Score:              60,
+				Grade:              "D",
+				Counts:             Counts{OK: 12, Info: 1, Warning: 3, Error: 2},
+				LastReport:         "s3://fred/ns1.html",
+				Results: []NamespaceResult{
+					{Namespace: "ns1", Score: 90, Grade: "A", Counts: Counts{OK: 10, Warning: 1}, Reports: []string{"s3://fred/ns1.html"}},
+					{Namespace: "ns2", Score: 60, Grade: "D", Counts: Counts{OK: 2, Info: 1, Warning: 2, Error: 2}},
+				},
+			},
+		},
+		"one-off": {
+			ns:   "ns1",
+			spec: ScanSpec{},
+			e: &ScanStatus{
+				ObservedGeneration: 1,
+				LastScanTime:       timePtr(now),
+				Score:              90,
+				Grade:              "A",
+				Counts:             Counts{OK: 10, Warning: 1},
+				LastReport:         "s3://fred/ns1.html",
+				Results: []NamespaceResult{
+					{Namespace: "ns1", Score: 90, Grade: "A", Counts: Counts{OK: 10, Warning: 1}, Reports: []string{"s3://fred/ns1.html"}},
+				},
+			},
+		},
+		"not-due": {
+			ns:     "ns1",
+			spec:   ScanSpec{Schedule: "0 */6 * * *"},
+			status: ScanStatus{ObservedGeneration: 1, LastScanTime: timePtr(now.Add(-time.Hour))},
+			after:  90 * time.Minute,
+		},
+		"done": {
+			ns:     "ns1",
+			status: ScanStatus{ObservedGeneration: 1, LastScanTime: &last},
+		},
+		"forbidden": {
+			ns:   "ns1",
+			spec: ScanSpec{Namespaces: []string{"ns2"}},
+			e: &ScanStatus{
+				ObservedGeneration: 1,
+				LastScanTime:       timePtr(now),
+				Results:            []NamespaceResult{},
+				Message:            `scanning namespace "ns2" requires the scan to live in namespace "popeye"`,
+			},
+		},
+		"failed": {
+			ns:   "popeye",
+			spec: ScanSpec{Namespaces: []string{"ns1", "ns3"}},
+			e: &ScanStatus{
+				ObservedGeneration: 1,
+				LastScanTime:       timePtr(now),
+				Score:              90,
+				Grade:              "A",
+				Counts:             Counts{OK: 10, Warning: 1},
+				LastReport:         "s3://fred/ns1.html",
+				Results: []NamespaceResult{
+					{Namespace: "ns1", Score: 90, Grade: "A", Counts: Counts{OK: 10, Warning: 1}, Reports: []string{"s3://fred/ns1.html"}},
+				},
+				Message: `namespace "ns3": boom`,
+			},
+		},
+		"output-failed": {
+			ns:   "popeye",
+			spec: ScanSpec{Namespaces: []string{"ns1", "ns4"}},
+			e: &ScanStatus{
+				ObservedGeneration: 1,
+				LastScanTime:       timePtr(now),
+				Score:              70,
+				Grade:              "C",
+				Counts:             Counts{OK: 13, Warning: 2},
+				LastReport:         "s3://fred/ns1.html",
+				Results: []NamespaceResult{
+					{Namespace: "ns1", Score: 90, Grade: "A", Counts: Counts{OK: 10, Warning: 1}, Reports: []string{"s3://fred/ns1.html"}},
+					{Namespace: "ns4", Score: 70, Grade: "C", Counts: Counts{OK: 3, Warning: 1}},
+				},
+				Message: `namespace "ns4": output html s3://blee failed: denied`,
+			},
+		},
+		"tenant-include": {
+			ns:      "ns1",
+			spec:    ScanSpec{Spinach: &ConfigMapRef{Name: "include"}},
+			scanner: makeSpinachScanner(),
+			e: &ScanStatus{
+				ObservedGeneration: 1,
+				LastScanTime:       timePtr(now),
+				Results:            []NamespaceResult{},
+				Message:            `namespace "ns1": spinach configmap ns1/include: includes are reserved to scans in namespace "popeye"`,
+			},
+		},
+		"tenant-webhook": {
+			ns:      "ns1",
+			spec:    ScanSpec{Spinach: &ConfigMapRef{Name: "webhook"}},
+			scanner: makeSpinachScanner(),
+			e: &ScanStatus{
+				ObservedGeneration: 1,
+				LastScanTime:       timePtr(now),
+				Results:            []NamespaceResult{},
+				Message:            `namespace "ns1": spinach configmap ns1/webhook: webhooks are reserved to scans in namespace "popeye"`,
+			},
+		},
+		"bad-schedule": {
+			ns:   "ns1",
+			spec: ScanSpec{Schedule: "fred"},
+			e: &ScanStatus{
+				ObservedGeneration: 1,
+				Message:            `invalid schedule "fred": expected exactly 5 fields, found 1: [fred]`,
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			s := PopeyeScan{
+				TypeMeta:   metav1.TypeMeta{APIVersion: GVR.GroupVersion().String(), Kind: Kind},
+				ObjectMeta: metav1.ObjectMeta{Namespace: u.ns, Name: "scan", Generation: 1},
+				Spec:       u.spec,
+				Status:     u.status,
+			}
+			raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&s)
+			assert.NoError(t, err)
+			o := &unstructured.Unstructured{Object: raw}
+
+			dial := fake.NewSimpleDynamicClientWithCustomListKinds(
+				runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{GVR: Kind + "List"},
+				o,
+			)
+			sc := u.scanner
+			if sc == nil {
+				sc = makeScanner()
+			}
+			c := NewController(dial, sc, "popeye", 0)
+			c.clock = clocktesting.NewFakeClock(now)
+			assert.NoError(t, c.informer.GetIndexer().Add(o))
+
+			after, err := c.reconcile(context.Background(), u.ns+"/scan")
+			assert.NoError(t, err)
+			assert.Equal(t, u.after, after)
+
+			got, err := dial.Resource(GVR).Namespace(u.ns).Get(context.Background(), "scan", metav1.GetOptions{})
+			assert.NoError(t, err)
+			var actual PopeyeScan
+			assert.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(got.Object, &actual))
+			if u.e == nil {
+				assert.Equal(t, u.status, actual.Status)
+				return
+			}
+			assert.Equal(t, *u.e, actual.Status)
+		})
+	}
+}
+
+// Helpers...
+
+func makeScanner() scanner {
+	return scanner{
+		"ns1": {res: &NamespaceResult{Score: 90, Grade: "A", Counts: Counts{OK: 10, Warning: 1}, Reports: []string{"s3://fred/ns1.html"}}},
+		"ns2": {res: &NamespaceResult{Score: 60, Grade: "D", Counts: Counts{OK: 2, Info: 1, Warning: 2, Error: 2}}},
+		"ns4": {
+			res: &NamespaceResult{Score: 70, Grade: "C", Counts: Counts{OK: 3, Warning: 1}},
+			err: errors.New("output html s3://blee failed: denied"),
+		},
+	}
+}
+
+func makeSpinachScanner() Scanner {
+	clt := kfake.NewSimpleClientset(
+		&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "include"},
+			Data:       map[string]string{DefaultSpinachKey: "include:\n- /etc/passwd\npopeye: {}\n"},
+		},
+		&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "webhook"},
+			Data:       map[string]string{DefaultSpinachKey: "popeye:\n  webhooks:\n  - name: fred\n    url: http://169.254.169.254\n"},
+		},
+	)
+
+	return NewScanner(config.NewFlags(), clt, "fred", "popeye", nil)
+}
+
+func timePtr(t time.Time) *metav1.Time {
+	mt := metav1.NewTime(t)
+	return &mt
+}
@@ -0,0 +1,297 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package operator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/derailed/popeye/pkg"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/derailed/popeye/pkg/storage"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Formats lists the supported output formats.
+var Formats = []string{
+	"standard",
+	"jurassic",
+	"yaml",
+	"json",
+	"html",
+	"junit",
+	"csv",
+	"tsv",
+	"codequality",
+	"markdown",
+	"sarif",
+	"policyreport",
+}
+
+type popeyeScanner struct {
+	base    *config.Flags
+	clt     kubernetes.Interface
+	cluster string
+	adminNS string
+	allowed []string
+}
+
+// NewScanner returns a scanner running Popeye with the given connection flags.
+// Scans outside the admin namespace may only store reports under the allowed
+// storage URIs.
+func NewScanner(base *config.Flags, clt kubernetes.Interface, cluster, adminNS string, allowed []string) Scanner {
+	return &popeyeScanner{base: base, clt: clt, cluster: cluster, adminNS: adminNS, allowed: allowed}
+}
+
+// Scan scans a namespace and stores the requested reports.
+func (p *popeyeScanner) Scan(ctx context.Context, s *PopeyeScan, ns string) (*NamespaceResult, error) {
+	for _, o := range s.Spec.Outputs {
+		if !slices.Contains(Formats, o.Format) {
+			return nil, fmt.Errorf("invalid output format %q", o.Format)
+		}
+		if err := p.checkOutput(s, o); err != nil {
+			return nil, err
+		}
+	}
+	flags, err := p.flags(s, ns)
+	if err != nil {
+		return nil, err
+	}
+	if s.Spec.Spinach != nil {
+		file, err := p.spinach(ctx, s)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(file)
+		flags.Spinach = &file
+	}
+
+	pop, err := pkg.NewPopeye(flags, &log.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("popeye configuration load failed: %w", err)
+	}
+	if err := pop.Init(); err != nil {
+		return nil, err
+	}
+	pop.SetOutputTarget(pkg.NopCloser(new(bytes.Buffer)))
+	if _, _, err := pop.Lint(); err != nil {
+		return nil, err
+	}
+
+	res := newResult(pop)
+	var errs error
+	for _, o := range s.Spec.Outputs {
+		loc, err := p.store(ctx, pop, s, ns, o)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("output %s %s failed: %w", o.Format, o.URI, err))
+			continue
+		}
+		res.Reports = append(res.Reports, loc)
+	}
+
+	return res, errs
+}
+
+// checkOutput ensures scans outside the admin namespace only store reports
+// under the allowed storage URIs. Local files and endpoint overrides are
+// reserved to admin scans as they use the operator filesystem and identity.
+func (p *popeyeScanner) checkOutput(s *PopeyeScan, o Output) error {
+	if s.Namespace == p.adminNS {
+		return nil
+	}
+	loc, err := storage.Parse(o.URI)
+	if err != nil {
+		return err
+	}
+	if loc.Kind == storage.FileKind {
+		return fmt.Errorf("output %q: file storage is reserved to scans in namespace %q", o.URI, p.adminNS)
+	}
+	if o.Endpoint != "" {
+		return fmt.Errorf("output %q: endpoint overrides are reserved to scans in namespace %q", o.URI, p.adminNS)
+	}
+	if !slices.ContainsFunc(p.allowed, func(a string) bool { return allowedOutput(a, loc) }) {
+		return fmt.Errorf("output %q is not allowed. [%s]", o.URI, strings.Join(p.allowed, ","))
+	}
+
+	return nil
+}
+
+// allowedOutput returns true if a storage location lives under an allowed URI.
+func allowedOutput(allowed string, loc storage.Location) bool {
+	al, err := storage.Parse(allowed)
+	if err != nil || al.Kind == storage.FileKind || al.Kind != loc.Kind || al.Bucket != loc.Bucket {
+		return false
+	}
+	prefix, want := cleanPrefix(loc.Prefix), cleanPrefix(al.Prefix)
+
+	return want == "" || prefix == want || strings.HasPrefix(prefix, want+"/")
+}
+
+// cleanPrefix returns an object key prefix as resolved by Location.Key.
+func cleanPrefix(p string) string {
+	return strings.TrimPrefix(path.Clean("/"+p), "/")
+}
+
+func (p *popeyeScanner) flags(s *PopeyeScan, ns string) (*config.Flags, error) {
+	f := config.NewFlags()
+	f.ConfigFlags = connFlags(p.base.ConfigFlags)
+	f.InClusterName = &p.cluster
+	f.Output = strPtr("json")
+	f.Sections = &s.Spec.Sections
+	if s.Spec.Profile != "" {
+		f.Profile = strPtr(s.Spec.Profile)
+	}
+	if ns == "" {
+		f.AllNamespaces = boolPtr(true)
+	} else {
+		f.Namespace = strPtr(ns)
+	}
+
+	return f, f.Validate()
+}
+
+// spinach dumps the spinach ConfigMap content to a temporary file.
+func (p *popeyeScanner) spinach(ctx context.Context, s *PopeyeScan) (string, error) {
+	ref := s.Spec.Spinach
+	key := ref.Key
+	if key == "" {
+		key = DefaultSpinachKey
+	}
+	cm, err := p.clt.CoreV1().ConfigMaps(s.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("spinach configmap lookup failed: %w", err)
+	}
+	raw, ok := cm.Data[key]
+	if !ok {
+		return "", fmt.Errorf("spinach configmap %s/%s has no key %q", s.Namespace, ref.Name, key)
+	}
+	if err := p.checkSpinach(s, raw); err != nil {
+		return "", fmt.Errorf("spinach configmap %s/%s: %w", s.Namespace, ref.Name, err)
+	}
+	f, err := os.CreateTemp("", "popeye-spinach-*.yaml")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := f.WriteString(raw); err != nil {
+		return "", err
+	}
+
+	return f.Name(), f.Close()
+}
+
+// checkSpinach ensures spinach files of scans outside the admin namespace
+// neither include other files nor register webhooks. Both would use the
+// operator filesystem and identity.
+func (p *popeyeScanner) checkSpinach(s *PopeyeScan, raw string) error {
+	if s.Namespace == p.adminNS {
+		return nil
+	}
+	var sp config.Spinach
+	if err := yaml.Unmarshal([]byte(raw), &sp); err != nil {
+		return fmt.Errorf("invalid spinach file: %w", err)
+	}
+	if len(sp.Include) > 0 {
+		return fmt.Errorf("includes are reserved to scans in namespace %q", p.adminNS)
+	}
+	if len(sp.Popeye.Webhooks) > 0 {
+		return fmt.Errorf("webhooks are reserved to scans in namespace %q", p.adminNS)
+	}
+
+	return nil
+}
+
+// store renders and uploads a report. It returns the report location.
+func (p *popeyeScanner) store(ctx context.Context, pop *pkg.Popeye, s *PopeyeScan, ns string, o Output) (string, error) {
+	var buf bytes.Buffer
+	if err := pop.Render(o.Format, &buf); err != nil {
+		return "", err
+	}
+	b, err := storage.New(ctx, o.URI, storage.Options{Region: o.Region, Endpoint: o.Endpoint})
+	if err != nil {
+		return "", err
+	}
+	if ns == "" {
+		ns = "all"
+	}
+	at := time.Now().UTC()
+	dir := path.Join(s.Namespace, s.Name, ns)
+	name := fmt.Sprintf("popeye-scan-%d.%s", at.UnixNano(), pkg.FileExt(o.Format))
+	if err := b.Put(ctx, path.Join(dir, name), pkg.ContentType(o.Format), &buf); err != nil {
+		return "", err
+	}
+	bb := pop.Builder()
+	score, _ := bb.ToScore()
+	e := storage.Entry{Timestamp: at, Score: score, Grade: bb.Report.Grade, Key: name}
+	if err := storage.Archive(ctx, b, dir, bb.ClusterName, bb.ContextName, e, storage.Retention{Keep: s.Spec.KeepLast}, at); err != nil {
+		log.Warn().Err(err).Msgf("Report archive failed for %s/%s", s.Namespace, s.Name)
+	}
+
+	return location(o.URI, path.Join(dir, name)), nil
+}
+
+func newResult(pop *pkg.Popeye) *NamespaceResult {
+	b := pop.Builder()
+	score, _ := b.ToScore()
+	res := NamespaceResult{Score: score, Grade: b.Report.Grade, Reports: make([]string, 0)}
+	for _, section := range b.Report.Sections {
+		if section.Tally == nil {
+			continue
+		}
+		res.Counts.Add(Counts{
+			OK:      section.Tally.OkCount(),
+			Info:    section.Tally.InfoCount(),
+			Warning: section.Tally.WarnCount(),
+			Error:   section.Tally.ErrCount(),
+		})
+	}
+
+	return &res
+}
+
+// location returns a report location given its storage URI and key.
+func location(uri, key string) string {
+	loc, err := storage.Parse(uri)
+	if err != nil {
+		return uri
+	}
+	if loc.Kind == storage.FileKind {
+		return "file://" + path.Join(loc.Prefix, key)
+	}
+
+	return fmt.Sprintf("%s://%s/%s", loc.Kind, loc.Bucket, loc.Key(key))
+}
+
+// connFlags copies the cluster connection flags.
+func connFlags(base *genericclioptions.ConfigFlags) *genericclioptions.ConfigFlags {
+	f := genericclioptions.NewConfigFlags(false)
+	if base == nil {
+		return f
+	}
+	f.KubeConfig, f.Context, f.ClusterName, f.AuthInfoName = base.KubeConfig, base.Context, base.ClusterName, base.AuthInfoName
+	f.Impersonate, f.ImpersonateGroup = base.Impersonate, base.ImpersonateGroup
+	f.Insecure, f.CAFile, f.CertFile, f.KeyFile = base.Insecure, base.CAFile, base.CertFile, base.KeyFile
+	f.BearerToken, f.Timeout, f.APIServer = base.BearerToken, base.Timeout, base.APIServer
+
+	return f
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package operator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocation(t *testing.T) {
+	uu := map[string]struct {
+		uri, key, e string
+	}{
+		"s3": {
+			uri: "s3://fred/popeye",
+			key: "ns1/scan/ns1/popeye-scan-1.html",
+			e:   "s3://fred/popeye/ns1/scan/ns1/popeye-scan-1.html",
+		},
+		"gcs": {
+			uri: "gs://fred",
+			key: "ns1/scan/all/popeye-scan-1.json",
+			e:   "gs://fred/ns1/scan/all/popeye-scan-1.json",
+		},
+		"file": {
+			uri: "file:///tmp/popeye",
+			key: "ns1/scan/ns1/popeye-scan-1.html",
+			e:   "file:///tmp/popeye/ns1/scan/ns1/popeye-scan-1.html",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, location(u.uri, u.key))
+		})
+	}
+}
+
+func TestCheckOutput(t *testing.T) {
+	uu := map[string]struct {
+		ns  string
+		o   Output
+		err string
+	}{
+		"admin-file": {
+			ns: "popeye",
+			o:  Output{URI: "file:///tmp/popeye"},
+		},
+		"admin-bucket": {
+			ns: "popeye",
+			o:  Output{URI: "s3://blee", Endpoint: "http://localhost:9000"},
+		},
+		"allowed": {
+			ns: "fred",
+			o:  Output{URI: "s3://reports/popeye/fred"},
+		},
+		"allowed-exact": {
+			ns: "fred",
+			o:  Output{URI: "gs://team"},
+		},
+		"file": {
+			ns:  "fred",
+			o:   Output{URI: "file:///tmp/popeye"},
+			err: `output "file:///tmp/popeye": file storage is reserved to scans in namespace "popeye"`,
+		},
+		"endpoint": {
+			ns:  "fred",
+			o:   Output{URI: "s3://reports/popeye", Endpoint: "http://evil"},
+			err: `output "s3://reports/popeye": endpoint overrides are reserved to scans in namespace "popeye"`,
+		},
+		"bucket": {
+			ns:  "fred",
+			o:   Output{URI: "s3://blee/popeye"},
+			err: `output "s3://blee/popeye" is not allowed. [s3://reports/popeye,gs://team]`,
+		},
+		"prefix": {
+			ns:  "fred",
+			o:   Output{URI: "s3://reports/popeyes"},
+			err: `output "s3://reports/popeyes" is not allowed. [s3://reports/popeye,gs://team]`,
+		},
+		"traversal": {
+			ns:  "fred",
+			o:   Output{URI: "s3://reports/popeye/../other"},
+			err: `output "s3://reports/popeye/../other" is not allowed. [s3://reports/popeye,gs://team]`,
+		},
+		"kind": {
+			ns:  "fred",
+			o:   Output{URI: "minio://reports/popeye"},
+			err: `output "minio://reports/popeye" is not allowed. [s3://reports/popeye,gs://team]`,
+		},
+	}
+
+	p := popeyeScanner{adminNS: "popeye", allowed: []string{"s3://reports/popeye", "gs://team"}}
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			s := PopeyeScan{}
+			s.Namespace = u.ns
+			err := p.checkOutput(&s, u.o)
+			if u.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, u.err)
+		})
+	}
+}
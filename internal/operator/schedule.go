@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package operator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// nextScan returns when the scan should run next. A zero time denotes a scan
+// that should not run again until its spec changes. New or updated scans run
+// right away.
+func nextScan(s *PopeyeScan, now time.Time) (time.Time, error) {
+	if s.Spec.Suspend {
+		return time.Time{}, nil
+	}
+	sched, err := parseSchedule(s.Spec.Schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if s.Status.LastScanTime == nil || s.Status.ObservedGeneration != s.Generation {
+		return now, nil
+	}
+	if sched == nil {
+		return time.Time{}, nil
+	}
+
+	return sched.Next(s.Status.LastScanTime.Time), nil
+}
+
+func parseSchedule(spec string) (cron.Schedule, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	sched, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+
+	return sched, nil
+}
+
+// scanNamespaces returns the namespaces to scan. Scans outside the operator
+// namespace may only target their own namespace.
+func scanNamespaces(s *PopeyeScan, adminNS string) ([]string, error) {
+	if len(s.Spec.Namespaces) == 0 {
+		return []string{s.Namespace}, nil
+	}
+	if s.Namespace == adminNS {
+		return s.Spec.Namespaces, nil
+	}
+	for _, ns := range s.Spec.Namespaces {
+		if ns != s.Namespace {
+			return nil, fmt.Errorf("scanning namespace %q requires the scan to live in namespace %q", ns, adminNS)
+		}
+	}
+
+	return []string{s.Namespace}, nil
+}
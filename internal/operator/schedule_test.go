@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package operator
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNextScan(t *testing.T) {
+	now := time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)
+	last := metav1.NewTime(time.Date(2024, 3, 1, 6, 0, 0, 0, time.UTC))
+
+	uu := map[string]struct {
+		spec   ScanSpec
+		gen    int64
+		status ScanStatus
+		e      time.Time
+		err    error
+	}{
+		"new": {
+			spec: ScanSpec{Schedule: "0 */6 * * *"},
+			gen:  1,
+			e:    now,
+		},
+		"changed": {
+			spec:   ScanSpec{Schedule: "0 */6 * * *"},
+			gen:    2,
+			status: ScanStatus{ObservedGeneration: 1, LastScanTime: &last},
+			e:      now,
+		},
+		"scheduled": {
+			spec:   ScanSpec{Schedule: "0 */6 * * *"},
+			gen:    1,
+			status: ScanStatus{ObservedGeneration: 1, LastScanTime: &last},
+			e:      time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		},
+		"every": {
+			spec:   ScanSpec{Schedule: "@every 1h"},
+			gen:    1,
+			status: ScanStatus{ObservedGeneration: 1, LastScanTime: &last},
+			e:      time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC),
+		},
+		"one-off": {
+			gen:    1,
+			status: ScanStatus{ObservedGeneration: 1, LastScanTime: &last},
+		},
+		"suspended": {
+			spec: ScanSpec{Schedule: "0 */6 * * *", Suspend: true},
+			gen:  1,
+		},
+		"toast": {
+			spec: ScanSpec{Schedule: "fred"},
+			gen:  1,
+			err:  errors.New(`invalid schedule "fred": expected exactly 5 fields, found 1: [fred]`),
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			s := PopeyeScan{Spec: u.spec, Status: u.status}
+			s.Generation = u.gen
+			at, err := nextScan(&s, now)
+			if u.err != nil {
+				assert.Equal(t, u.err.Error(), err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, u.e, at.UTC())
+		})
+	}
+}
+
+func TestScanNamespaces(t *testing.T) {
+	uu := map[string]struct {
+		ns, adminNS string
+		nn, e       []string
+		err         error
+	}{
+		"default": {
+			ns:      "fred",
+			adminNS: "popeye",
+			e:       []string{"fred"},
+		},
+		"own": {
+			ns:      "fred",
+			adminNS: "popeye",
+			nn:      []string{"fred"},
+			e:       []string{"fred"},
+		},
+		"admin": {
+			ns:      "popeye",
+			adminNS: "popeye",
+			nn:      []string{"fred", "blee"},
+			e:       []string{"fred", "blee"},
+		},
+		"admin-all": {
+			ns:      "popeye",
+			adminNS: "popeye",
+			nn:      []string{AllNamespaces},
+			e:       []string{AllNamespaces},
+		},
+		"other": {
+			ns:      "fred",
+			adminNS: "popeye",
+			nn:      []string{"fred", "blee"},
+			err:     errors.New(`scanning namespace "blee" requires the scan to live in namespace "popeye"`),
+		},
+		"all": {
+			ns:      "fred",
+			adminNS: "popeye",
+			nn:      []string{AllNamespaces},
+			err:     errors.New(`scanning namespace "*" requires the scan to live in namespace "popeye"`),
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			s := PopeyeScan{Spec: ScanSpec{Namespaces: u.nn}}
+			s.Namespace = u.ns
+			nn, err := scanNamespaces(&s, u.adminNS)
+			assert.Equal(t, u.err, err)
+			assert.Equal(t, u.e, nn)
+		})
+	}
+}
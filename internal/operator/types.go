@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package operator
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// Group the PopeyeScan API group.
+	Group = "popeyecli.io"
+
+	// Version the PopeyeScan API version.
+	Version = "v1alpha1"
+
+	// Kind the PopeyeScan kind.
+	Kind = "PopeyeScan"
+
+	// Component the operator field manager.
+	Component = "popeye-operator"
+
+	// AllNamespaces requests a cluster wide scan.
+	AllNamespaces = "*"
+
+	// DefaultSpinachKey the default spinach ConfigMap key.
+	DefaultSpinachKey = "spinach.yaml"
+)
+
+// GVR the PopeyeScan resource.
+var GVR = schema.GroupVersionResource{Group: Group, Version: Version, Resource: "popeyescans"}
+
+// PopeyeScan represents a scan request.
+type PopeyeScan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ScanSpec   `json:"spec"`
+	Status ScanStatus `json:"status,omitempty"`
+}
+
+// ScanSpec represents the desired scan.
+type ScanSpec struct {
+	// Schedule a cron schedule ie 0 */6 * * * or @every 1h. Scans run once
+	// per spec change when omitted.
+	Schedule string `json:"schedule,omitempty"`
+
+	// Suspend pauses scans.
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Namespaces the namespaces to scan. Defaults to the scan namespace.
+	// Use * to scan the whole cluster.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Sections the linters to run. Defaults to all linters.
+	Sections []string `json:"sections,omitempty"`
+
+	// Profile a built-in spinach profile.
+	Profile string `json:"profile,omitempty"`
+
+	// Spinach references a ConfigMap holding a spinach file.
+	Spinach *ConfigMapRef `json:"spinach,omitempty"`
+
+	// Outputs the reports to store.
+	Outputs []Output `json:"outputs,omitempty"`
+
+	// KeepLast the number of reports to keep per output. Zero keeps all reports.
+	KeepLast int `json:"keepLast,omitempty"`
+}
+
+// ConfigMapRef references a ConfigMap key in the scan namespace.
+type ConfigMapRef struct {
+	Name string `json:"name"`
+	Key  string `json:"key,omitempty"`
+}
+
+// Output represents a report output.
+type Output struct {
+	// Format the report format ie json, html, sarif...
+	Format string `json:"format"`
+
+	// URI the storage URI ie s3://bucket/dir, gs://bucket/dir, azblob://container/dir.
+	URI string `json:"uri"`
+
+	// Region the bucket region if any.
+	Region string `json:"region,omitempty"`
+
+	// Endpoint the storage endpoint if any.
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// ScanStatus represents the last scan outcome.
+type ScanStatus struct {
+	ObservedGeneration int64             `json:"observedGeneration,omitempty"`
+	LastScanTime       *metav1.Time      `json:"lastScanTime,omitempty"`
+	NextScanTime       *metav1.Time      `json:"nextScanTime"`
+	Score              int               `json:"score"`
+	Grade              string            `json:"grade"`
+	Counts             Counts            `json:"counts"`
+	LastReport         string            `json:"lastReport"`
+	Results            []NamespaceResult `json:"results"`
+	Message            string            `json:"message"`
+}
+
+// Counts tallies resources by highest issue severity.
+type Counts struct {
+	OK      int `json:"ok"`
+	Info    int `json:"info"`
+	Warning int `json:"warning"`
+	Error   int `json:"error"`
+}
+
+// Add accumulates counts.
+func (c *Counts) Add(o Counts) {
+	c.OK += o.OK
+	c.Info += o.Info
+	c.Warning += o.Warning
+	c.Error += o.Error
+}
+
+// NamespaceResult represents a namespace scan outcome.
+type NamespaceResult struct {
+	Namespace string   `json:"namespace"`
+	Score     int      `json:"score"`
+	Grade     string   `json:"grade"`
+	Counts    Counts   `json:"counts"`
+	Reports   []string `json:"reports,omitempty"`
+}
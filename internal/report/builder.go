@@ -10,9 +10,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"html/template"
 	"sort"
 	"strings"
-	"text/template"
 	"time"
 
 	"github.com/derailed/popeye/internal/issues"
@@ -29,6 +29,12 @@ type Builder struct {
 	Report      Report `json:"popeye" yaml:"popeye"`
 	ClusterName string
 	ContextName string
+	scoring     config.Scoring
+	previous    *int
+	violations  []Violation
+	provenance  *Provenance
+	stats       *Stats
+	teams       TeamFunc
 }
 
 // NewBuilder returns a new instance.
@@ -43,6 +49,11 @@ func (b *Builder) SetClusterContext(cl, ct string) {
 	b.Report.Timestamp = time.Now().Format(time.RFC3339)
 }
 
+// SetPreviousScore records the score of the last scan to report score trends.
+func (b *Builder) SetPreviousScore(score int) {
+	b.previous = &score
+}
+
 // HasContent checks if we actually have anything to report.
 func (b *Builder) HasContent() bool {
 	return b.Report.sectionsCount != 0
@@ -53,26 +64,82 @@ func (b *Builder) AddError(err error) {
 	b.Report.Errors = append(b.Report.Errors, err)
 }
 
-// AddSection adds a linter section to the report.
-func (b *Builder) AddSection(gvr types.GVR, singular string, o issues.Outcome, t *Tally) {
+// AddSection adds a linter section to the report given the section lint level.
+func (b *Builder) AddSection(gvr types.GVR, singular string, level rules.Level, o issues.Outcome, t *Tally) {
 	section := Section{
 		Title:    strings.ToLower(gvr.R()),
 		GVR:      gvr.String(),
 		singular: singular,
+		level:    level,
 		Tally:    t,
 		Outcome:  o,
 	}
 	b.Report.Sections = append(b.Report.Sections, section)
 	if t.IsValid() {
 		b.Report.sectionsCount++
-		b.Report.totalScore += t.Score()
+		b.score(section.Title, t)
+	}
+}
+
+// AddSkipped records resources excluded from the given linter section.
+func (b *Builder) AddSkipped(gvr types.GVR, fqns ...string) {
+	if len(fqns) == 0 {
+		return
+	}
+	for i := range b.Report.Sections {
+		if b.Report.Sections[i].GVR == gvr.String() {
+			b.Report.Sections[i].skipped = append(b.Report.Sections[i].skipped, fqns...)
+			return
+		}
 	}
 }
 
 // ToJunit dumps scan to JUnit.
-func (b *Builder) ToJunit(level rules.Level) (string, error) {
+func (b *Builder) ToJunit() (string, error) {
+	b.finalize()
+	raw, err := junitMarshal(b)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
+// ToSARIF dumps scan to SARIF using the given codes as rules.
+func (b *Builder) ToSARIF(cc rules.Glossary) (string, error) {
+	b.finalize()
+	raw, err := sarifMarshal(b, cc)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
+// ToCSV dumps scan to comma separated values.
+func (b *Builder) ToCSV() (string, error) {
+	return b.toDelimited(',')
+}
+
+// ToTSV dumps scan to tab separated values.
+func (b *Builder) ToTSV() (string, error) {
+	return b.toDelimited('\t')
+}
+
+func (b *Builder) toDelimited(comma rune) (string, error) {
+	b.finalize()
+	raw, err := csvMarshal(b, comma)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
+// ToCodeQuality dumps scan to a GitLab Code Quality report.
+func (b *Builder) ToCodeQuality() (string, error) {
 	b.finalize()
-	raw, err := junitMarshal(b, level)
+	raw, err := codeQualityMarshal(b)
 	if err != nil {
 		return "", err
 	}
@@ -80,10 +147,20 @@ func (b *Builder) ToJunit(level rules.Level) (string, error) {
 	return string(raw), nil
 }
 
+// ToMarkdown dumps scan to Markdown listing at most max issues.
+func (b *Builder) ToMarkdown(max int) (string, error) {
+	b.finalize()
+
+	return string(markdownMarshal(b, max)), nil
+}
+
 func (b *Builder) finalize() {
-	score := b.Report.totalScore / b.Report.sectionsCount
+	score := 100
+	if b.Report.totalWeight > 0 {
+		score = int(b.Report.totalScore / b.Report.totalWeight)
+	}
 	b.Report.Score = score
-	b.Report.Grade = Grade(score)
+	b.Report.Grade = gradeFor(score, b.scoring.GradeCutoffs())
 }
 
 // ToYAML dumps scan to YAML.
@@ -108,23 +185,33 @@ func (b *Builder) ToJSON() (string, error) {
 	return string(raw), nil
 }
 
-// ToHTML dumps scan to HTML.
+// ToHTML dumps scan to a self-contained interactive HTML report.
 func (b *Builder) ToHTML() (string, error) {
 	b.finalize()
 
-	fMap := template.FuncMap{
-		"toEmoji": toEmoji,
-		"toTitle": Titleize,
-		"isRoot":  isRoot,
-		"list":    b.Report.ListSections,
+	scan, err := json.Marshal(b)
+	if err != nil {
+		return "", err
 	}
-	tpl, err := template.New("sanitize").Funcs(fMap).Parse(htmlReport)
+	res, err := json.Marshal(b.Report.resources())
+	if err != nil {
+		return "", err
+	}
+	tpl, err := template.New("sanitize").Parse(htmlReport)
 	if err != nil {
 		return "", err
 	}
 
 	buff := bytes.NewBufferString("")
-	if err := tpl.Execute(buff, b); err != nil {
+	err = tpl.Execute(buff, struct {
+		*Builder
+		Scan, Resources template.JS
+	}{
+		Builder:   b,
+		Scan:      template.JS(scan),
+		Resources: template.JS(res),
+	})
+	if err != nil {
 		return "", err
 	}
 
@@ -160,20 +247,36 @@ func (b *Builder) PrintSummary(s *ScanReport) {
 	b.finalize()
 	s.Open("SUMMARY", nil)
 	{
-		fmt.Fprint(s, s.Color(fmt.Sprintf("%-19s %s (%d)\n", "Your cluster score:", b.Report.Grade, b.Report.Score), ColorAqua))
-		for _, l := range s.Badge(b.Report.Score) {
+		label := "Your cluster score:"
+		if b.Report.Team != "" {
+			label = "Team score:"
+		}
+		fmt.Fprint(s, s.Color(fmt.Sprintf("%-19s %s (%d)%s\n", label, b.Report.Grade, b.Report.Score, b.trend()), ColorAqua))
+		for _, l := range s.GradeBadge(b.Report.Score, b.Report.Grade) {
 			fmt.Fprintf(s, "%s%s\n", strings.Repeat(" ", Width-20), l)
 		}
 	}
 	s.Close()
 }
 
+func (b *Builder) trend() string {
+	if b.previous == nil {
+		return ""
+	}
+	d := NewDeltaScore(rules.OkLevel, *b.previous, b.Report.Score, false)
+
+	return fmt.Sprintf(" %+d vs last scan", d.delta())
+}
+
 // PrintClusterInfo displays cluster information.
 func (b *Builder) PrintClusterInfo(s *ScanReport, metrics bool) {
 	cl := b.ClusterName
 	if cl == "" {
 		cl = "n/a"
 	}
+	if b.Report.Team != "" {
+		cl += "/" + b.Report.Team
+	}
 	s.Open(Titleize(fmt.Sprintf("General [%s] (%s)", cl, b.Report.Timestamp), -1), nil)
 	{
 		s.Print(rules.OkLevel, 1, "Connectivity")
@@ -206,10 +309,11 @@ func (b *Builder) PrintHeader(s *ScanReport) {
 }
 
 // PrintReport prints out scan report to screen
-func (b *Builder) PrintReport(level rules.Level, s *ScanReport) {
+func (b *Builder) PrintReport(s *ScanReport) {
 	for _, section := range b.Report.Sections {
 		var any bool
-		s.Open(Titleize(section.Title, len(section.Outcome)), section.Tally)
+		level := section.level
+		s.Open(Titleize(section.name(), len(section.Outcome)), section.Tally)
 		{
 			kk := make([]string, 0, len(section.Outcome))
 			for k := range section.Outcome {
@@ -251,23 +355,3 @@ func Titleize(res string, count int) string {
 	}
 	return strings.ToUpper(fmt.Sprintf("%s (%d scanned)", res, count))
 }
-
-func isRoot(g string) bool {
-	return g == issues.Root
-}
-
-func toEmoji(level rules.Level) (s string) {
-	switch level {
-	case rules.ErrorLevel:
-		s = "fas fa-bomb"
-	case rules.WarnLevel:
-		s = "fas fa-radiation-alt"
-	case rules.InfoLevel:
-		s = "fas fa-info-circle"
-	case rules.OkLevel:
-		s = "far fa-check-circle"
-	default:
-		s = "fas fa-info-circle"
-	}
-	return s
-}
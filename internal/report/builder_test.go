@@ -24,12 +24,36 @@ func TestBuilderHtml(t *testing.T) {
 	}
 
 	ta.Rollup(o)
-	b.AddSection(types.NewGVR("fred"), "fred", o, ta)
+	b.AddSection(types.NewGVR("fred"), "fred", rules.OkLevel, o, ta)
 	b.AddError(errors.New("boom"))
 	s, err := b.ToHTML()
 
 	assert.Nil(t, err)
-	assert.Equal(t, reportHTML, s)
+	assert.Contains(t, s, `<span class="grade grade-A">A</span>`)
+	assert.Contains(t, s, `<script type="application/json" id="popeye-scan">{"popeye":{"report_time":"","score":100,"grade":"A","sections":[{"linter":"fred","gvr":"fred","tally":{"ok":1,"info":0,"warning":0,"error":0,"score":100},"issues":{"blee":[{"group":"__root__","gvr":"fred","level":0,"message":"Blah"}]}}],"errors":{"error":"boom"}},"ClusterName":"","ContextName":""}</script>`)
+	assert.Contains(t, s, `<script type="application/json" id="popeye-resources">{"fred":["blee"]}</script>`)
+	assert.NotRegexp(t, `(src|href)="https?://`, s)
+}
+
+func TestBuilderHtmlEscape(t *testing.T) {
+	b, ta := report.NewBuilder(), report.NewTally()
+	o := issues.Outcome{
+		"ns1/blee": issues.Issues{
+			issues.New(types.NewGVR("fred"), issues.Root, rules.WarnLevel, "</script><b>boom</b>"),
+		},
+		"ns1/duh": issues.Issues{},
+	}
+
+	ta.Rollup(o)
+	b.AddSection(types.NewGVR("fred"), "fred", rules.OkLevel, o, ta)
+	b.SetClusterContext("<c1>", "ct1")
+	s, err := b.ToHTML()
+
+	assert.Nil(t, err)
+	assert.Contains(t, s, `<span class="cluster">&lt;c1&gt;/ct1</span>`)
+	assert.Contains(t, s, `"message":"\u003c/script\u003e\u003cb\u003eboom\u003c/b\u003e"`)
+	assert.Contains(t, s, `{"fred":["ns1/blee","ns1/duh"]}`)
+	assert.NotContains(t, s, "<b>boom</b>")
 }
 
 func TestBuilderJunit(t *testing.T) {
@@ -41,14 +65,44 @@ func TestBuilderJunit(t *testing.T) {
 	}
 
 	ta.Rollup(o)
-	b.AddSection(types.NewGVR("fred"), "fred", o, ta)
+	b.AddSection(types.NewGVR("fred"), "fred", rules.OkLevel, o, ta)
 	b.AddError(errors.New("boom"))
-	s, err := b.ToJunit(rules.OkLevel)
+	s, err := b.ToJunit()
 
 	assert.Nil(t, err)
 	assert.Equal(t, reportJunit, s)
 }
 
+func TestBuilderJunitIssues(t *testing.T) {
+	b, ta := report.NewBuilder(), report.NewTally()
+	gvr := types.NewGVR("v1/pods")
+	o := issues.Outcome{
+		"ns1/p2": issues.Issues{
+			issues.New(gvr, "c1", rules.WarnLevel, "[POP-101] Blee"),
+			issues.New(gvr, issues.Root, rules.ErrorLevel, "[POP-100] Blah"),
+		},
+		"ns1/p1": issues.Issues{},
+	}
+
+	ta.Rollup(o)
+	b.AddSection(gvr, "pod", rules.OkLevel, o, ta)
+	b.AddSkipped(gvr, "ns1/p1", "ns2/p3")
+	b.SetClusterContext("c1", "ctx1")
+	s, err := b.ToJunit()
+
+	assert.Nil(t, err)
+	assert.Contains(t, s, `<property name="cluster" value="c1"></property>`)
+	assert.Contains(t, s, `<property name="context" value="ctx1"></property>`)
+	assert.Contains(t, s, `<property name="grade" value="E"></property>`)
+	assert.Contains(t, s, `<testsuite name="pods" tests="3" failures="2" errors="1" skipped="2">`)
+	assert.Contains(t, s, "<testcase classname=\"ns1\" name=\"p1\">\n\t\t\t<skipped message=\"excluded by spinach configuration\"></skipped>\n\t\t</testcase>\n"+
+		"\t\t<testcase classname=\"ns1\" name=\"p2\">\n"+
+		"\t\t\t<failure message=\"c1: Blee\" type=\"warn\" code=\"POP-101\">code: POP-101&#xA;severity: warn&#xA;message: c1: Blee</failure>\n"+
+		"\t\t\t<error message=\"Blah\" type=\"error\" code=\"POP-100\">code: POP-100&#xA;severity: error&#xA;message: Blah</error>\n"+
+		"\t\t</testcase>\n"+
+		"\t\t<testcase classname=\"ns2\" name=\"p3\">\n\t\t\t<skipped message=\"excluded by spinach configuration\"></skipped>\n\t\t</testcase>")
+}
+
 func TestBuilderYAML(t *testing.T) {
 	b, ta := report.NewBuilder(), report.NewTally()
 	o := issues.Outcome{
@@ -58,7 +112,7 @@ func TestBuilderYAML(t *testing.T) {
 	}
 
 	ta.Rollup(o)
-	b.AddSection(types.NewGVR("fred"), "fred", o, ta)
+	b.AddSection(types.NewGVR("fred"), "fred", rules.OkLevel, o, ta)
 	b.AddError(errors.New("boom"))
 	s, err := b.ToYAML()
 
@@ -75,7 +129,7 @@ func TestBuilderJSON(t *testing.T) {
 	}
 
 	ta.Rollup(o)
-	b.AddSection(types.NewGVR("fred"), "fred", o, ta)
+	b.AddSection(types.NewGVR("fred"), "fred", rules.OkLevel, o, ta)
 	b.AddError(errors.New("boom"))
 	s, err := b.ToJSON()
 
@@ -83,6 +137,29 @@ func TestBuilderJSON(t *testing.T) {
 	assert.Equal(t, reportJSON, s)
 }
 
+func TestBuilderSARIF(t *testing.T) {
+	b, ta := report.NewBuilder(), report.NewTally()
+	gvr := types.NewGVR("v1/pods")
+	o := issues.Outcome{
+		"default/p1": issues.Issues{
+			issues.New(gvr, issues.Root, rules.ErrorLevel, "[POP-100] Blah"),
+			issues.New(types.NewGVR("containers"), "c1", rules.WarnLevel, "[POP-101] Blee"),
+		},
+		"default/p2": issues.Issues{},
+	}
+	cc := rules.Glossary{
+		101: {Message: "Blee", Severity: rules.WarnLevel},
+		100: {Message: "Blah", Severity: rules.ErrorLevel, Linters: []string{"pods"}, Description: "Duh", Remediation: "Fix it"},
+	}
+
+	ta.Rollup(o)
+	b.AddSection(gvr, "pod", rules.OkLevel, o, ta)
+	s, err := b.ToSARIF(cc)
+
+	assert.Nil(t, err)
+	assert.Equal(t, reportSARIF, s)
+}
+
 func TestPrintSummary(t *testing.T) {
 	b, ta := report.NewBuilder(), report.NewTally()
 	o := issues.Outcome{
@@ -92,7 +169,7 @@ func TestPrintSummary(t *testing.T) {
 	}
 
 	ta.Rollup(o)
-	b.AddSection(types.NewGVR("fred"), "fred", o, ta)
+	b.AddSection(types.NewGVR("fred"), "fred", rules.OkLevel, o, ta)
 	b.AddError(errors.New("boom"))
 
 	buff := bytes.NewBuffer([]byte(""))
@@ -102,6 +179,44 @@ func TestPrintSummary(t *testing.T) {
 	assert.Equal(t, summaryExp, buff.String())
 }
 
+func TestPrintSummaryTrend(t *testing.T) {
+	uu := map[string]struct {
+		prev *int
+		e    string
+	}{
+		"none": {
+			e: "Your cluster score: A (100)\n",
+		},
+		"better": {
+			prev: intPtr(97),
+			e:    "Your cluster score: A (100) +3 vs last scan\n",
+		},
+		"same": {
+			prev: intPtr(100),
+			e:    "Your cluster score: A (100) +0 vs last scan\n",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			b, o := report.NewBuilder(), issues.Outcome{"blee": issues.Issues{}}
+			b.AddSection(types.NewGVR("fred"), "fred", rules.OkLevel, o, report.NewTally().Rollup(o))
+			if u.prev != nil {
+				b.SetPreviousScore(*u.prev)
+			}
+			buff := bytes.NewBuffer([]byte(""))
+			b.PrintSummary(report.New(buff, true))
+
+			assert.Contains(t, buff.String(), u.e)
+		})
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
 func TestPrintHeader(t *testing.T) {
 	b, ta := report.NewBuilder(), report.NewTally()
 	o := issues.Outcome{
@@ -111,7 +226,7 @@ func TestPrintHeader(t *testing.T) {
 	}
 
 	ta.Rollup(o)
-	b.AddSection(types.NewGVR("fred"), "fred", o, ta)
+	b.AddSection(types.NewGVR("fred"), "fred", rules.OkLevel, o, ta)
 	b.AddError(errors.New("boom"))
 
 	buff := bytes.NewBuffer([]byte(""))
@@ -130,12 +245,12 @@ func TestPrintReport(t *testing.T) {
 	}
 
 	ta.Rollup(o)
-	b.AddSection(types.NewGVR("fred"), "fred", o, ta)
+	b.AddSection(types.NewGVR("fred"), "fred", rules.OkLevel, o, ta)
 	b.AddError(errors.New("boom"))
 
 	buff := bytes.NewBuffer([]byte(""))
 	san := report.New(buff, false)
-	b.PrintReport(rules.OkLevel, san)
+	b.PrintReport(san)
 
 	assert.Equal(t, reportExp, buff.String())
 }
@@ -162,9 +277,9 @@ func TestTitleize(t *testing.T) {
 // Helpers...
 
 var (
-	reportHTML  = "<html>\n<head>\n  <title>Popeye Scan Report</title>\n  <script src=\"https://kit.fontawesome.com/b45e86135f.js\" crossorigin=\"anonymous\"></script>\n</head>\n<style>\n  body {\n    background-color: #111;\n    color: white;\n    font-family: 'Gill Sans', 'Gill Sans MT', Calibri, 'Trebuchet MS', sans-serif;\n  }\n  .linter {\n    padding: 10px 30px;\n  }\n  ul.outcome {\n    list-style-type: disc;\n  }\n  div.clear {\n    display: block;\n  }\n  .outcome-score {\n    float: right;\n  }\n  div.outcome {\n    display: inline-block;\n  }\n  .issue {\n    text-align: right;\n  }\n  ul.issues {\n    display: block;\n    padding-left: 15px;\n  }\n  ul.sub-issues {\n    padding-left: 20px;\n  }\n  .section {\n    padding-top: 30px;\n  }\n  .section-title {\n    text-transform: uppercase;\n    float: left;\n  }\n  .scores {\n    text-align: right;\n  }\n  .msg {\n    display: block;\n  }\n  .section-score {\n    color: purple;\n  }\n  .scorer {\n    padding-right: 3px;\n  }\n  .level-0 {\n    color: rgb(65, 255, 65);\n  }\n  .level-1 {\n    color: rgb(2, 156, 207);\n  }\n  .level-2 {\n    color: rgb(255, 193, 77);\n  }\n  .level-3 {\n    color: rgb(199, 39, 39);\n  }\n  .grade-A {\n    color: rgb(65, 255, 65);\n  }\n  .grade-B {\n    color: rgb(2, 156, 207);\n  }\n  .grade-C {\n    color: rgb(255, 193, 77);\n  }\n  .grade-D {\n    color: rgb(199, 39, 39);\n  }\n  .grade-E {\n    color: rgb(199, 39, 39);\n  }\n  .grade-F {\n    color: rgb(199, 39, 39);\n  }\n  .grade {\n    font-size: 5em;\n  }\n  .container {\n    color: #38ABCC;\n  }\n  div.time {\n    font-style: italic;\n    text-transform: uppercase;\n    font-size: .8em;\n    color: gray;\n  }\n  span.cluster {\n    font-style: italic;\n    text-transform: uppercase;\n    color: greenyellow;\n  }\n  h3 {\n    border-bottom: 1px dashed black;\n    width: 50%;\n  }\n  span.cluster-score {\n    font-size: 3em;\n  }\n  div.score-summary {\n    flex: 3 1 auto;\n    font-size: 2em;\n    text-align: left;\n  }\n  div.title {\n    font-size: 3em;\n    text-align: center;\n  }\n  a.popeye-logo {\n    display: inline-block;\n  }\n  div.summary {\n    display: flex;\n    flex-flow: row wrap;\n    align-items: center;\n    font-weight: 2em;\n  }\n  img.logo {\n    max-width: 175px;\n    border-radius: 10px;\n    -webkit-filter: drop-shadow(8px 8px 10px #373831);\n    filter: drop-shadow(8px 8px 10px #373831);\n  }\n  div.a {\n    color: blue;\n    float: left;\n    display: block;\n  }\n  div.scorer {\n    text-align: right;\n  }\n</style>\n\n<body>\n  <div class=\"linter\">\n    <div class=\"title\">Popeye Scan Report</div>\n    <div class=\"summary\">\n      <a class=\"popeye-logo\" href=\"https://github.com/derailed/popeye\">\n        <img class=\"logo\" src=\"https://github.com/derailed/popeye/raw/master/assets/popeye_logo.png\" />\n      </a>\n      <div class=\"score-summary\">\n        Scanned\n        <span class=\"cluster\">/</span>\n        <div class=\"time\"></div>\n      </div>\n      <div class=\"scorer\">\n        <span class=\"grade grade-A\">A</span>\n        <span class=\"section-score cluster-score\"> 100 </span>\n      </div>\n    </div>\n    <div class=\"section\">\n      <hr />\n      <div class=\"section-title\">FRED (1 SCANNED)</div>\n      <div class=\"scores\">\n        <span class=\"scorer level-3\"> <i class=\"fas fa-bomb\"></i> 0 </span>\n        <span class=\"scorer level-2\"> <i class=\"fas fa-radiation-alt\"></i> 0 </span>\n        <span class=\"scorer level-1\"> <i class=\"fas fa-info-circle\"></i> 0 </span>\n        <span class=\"scorer level-0\"> <i class=\"far fa-check-circle\"></i> 1 </span>\n        <span class=\"section-score\">100%</span>\n      </div>\n      <ul class=\"outcome\">\n        <li>\n          <div class=\"outcome level-0\">blee</div>\n          <div class=\"outcome-score level-0\"><i class=\"far fa-check-circle\"></i></div>\n          <div class=\"clear\"></div>\n          <ul class=\"issues\">\n            <li><span class=\" msg level-0\"><i class=\"far fa-check-circle\"></i> Blah</span></li>\n          </ul>\n        </li>\n        </ul>\n      </div>\n    </div>\n</body>\n</html>"
-	reportJunit = "<testsuites name=\"Popeye\" report_time=\"\" tests=\"1\" failures=\"0\" errors=\"1\">\n\t<testsuite name=\"fred\" tests=\"1\" failures=\"0\" errors=\"0\">\n\t\t<properties>\n\t\t\t<property name=\"OK\" value=\"1\"></property>\n\t\t\t<property name=\"Info\" value=\"0\"></property>\n\t\t\t<property name=\"Warn\" value=\"0\"></property>\n\t\t\t<property name=\"Error\" value=\"0\"></property>\n\t\t\t<property name=\"Score\" value=\"100%\"></property>\n\t\t</properties>\n\t\t<testcase classname=\"\" name=\"blee\"></testcase>\n\t</testsuite>\n</testsuites>"
+	reportJunit = "<testsuites name=\"Popeye\" report_time=\"\" tests=\"1\" failures=\"0\" errors=\"1\">\n\t<properties>\n\t\t<property name=\"cluster\" value=\"\"></property>\n\t\t<property name=\"context\" value=\"\"></property>\n\t\t<property name=\"score\" value=\"100\"></property>\n\t\t<property name=\"grade\" value=\"A\"></property>\n\t</properties>\n\t<testsuite name=\"fred\" tests=\"1\" failures=\"0\" errors=\"0\">\n\t\t<properties>\n\t\t\t<property name=\"OK\" value=\"1\"></property>\n\t\t\t<property name=\"Info\" value=\"0\"></property>\n\t\t\t<property name=\"Warn\" value=\"0\"></property>\n\t\t\t<property name=\"Error\" value=\"0\"></property>\n\t\t\t<property name=\"Score\" value=\"100%\"></property>\n\t\t</properties>\n\t\t<testcase classname=\"\" name=\"blee\"></testcase>\n\t</testsuite>\n</testsuites>"
 	reportJSON  = "{\"popeye\":{\"report_time\":\"\",\"score\":100,\"grade\":\"A\",\"sections\":[{\"linter\":\"fred\",\"gvr\":\"fred\",\"tally\":{\"ok\":1,\"info\":0,\"warning\":0,\"error\":0,\"score\":100},\"issues\":{\"blee\":[{\"group\":\"__root__\",\"gvr\":\"fred\",\"level\":0,\"message\":\"Blah\"}]}}],\"errors\":{\"error\":\"boom\"}},\"ClusterName\":\"\",\"ContextName\":\"\"}"
+	reportSARIF = "{\n  \"$schema\": \"https://json.schemastore.org/sarif-2.1.0.json\",\n  \"version\": \"2.1.0\",\n  \"runs\": [\n    {\n      \"tool\": {\n        \"driver\": {\n          \"name\": \"popeye\",\n          \"informationUri\": \"https://popeyecli.io\",\n          \"rules\": [\n            {\n              \"id\": \"POP-100\",\n              \"shortDescription\": {\n                \"text\": \"Blah\"\n              },\n              \"fullDescription\": {\n                \"text\": \"Duh\"\n              },\n              \"help\": {\n                \"text\": \"Fix it\"\n              },\n              \"defaultConfiguration\": {\n                \"level\": \"error\"\n              },\n              \"properties\": {\n                \"tags\": [\n                  \"pods\"\n                ]\n              }\n            },\n            {\n              \"id\": \"POP-101\",\n              \"shortDescription\": {\n                \"text\": \"Blee\"\n              },\n              \"defaultConfiguration\": {\n                \"level\": \"warning\"\n              }\n            }\n          ]\n        }\n      },\n      \"results\": [\n        {\n          \"ruleId\": \"POP-100\",\n          \"ruleIndex\": 0,\n          \"level\": \"error\",\n          \"message\": {\n            \"text\": \"Blah\"\n          },\n          \"locations\": [\n            {\n              \"logicalLocations\": [\n                {\n                  \"name\": \"p1\",\n                  \"fullyQualifiedName\": \"v1/pods/default/p1\",\n                  \"kind\": \"resource\"\n                }\n              ]\n            }\n          ],\n          \"properties\": {\n            \"lintLevel\": \"ok\"\n          }\n        },\n        {\n          \"ruleId\": \"POP-101\",\n          \"ruleIndex\": 1,\n          \"level\": \"warning\",\n          \"message\": {\n            \"text\": \"Blee\"\n          },\n          \"locations\": [\n            {\n              \"logicalLocations\": [\n                {\n                  \"name\": \"p1\",\n                  \"fullyQualifiedName\": \"v1/pods/default/p1/c1\",\n                  \"kind\": \"resource\"\n                }\n              ]\n            }\n          ],\n          \"properties\": {\n            \"lintLevel\": \"ok\"\n          }\n        }\n      ]\n    }\n  ]\n}"
 	reportYAML  = "popeye:\n  report_time: \"\"\n  score: 100\n  grade: A\n  sections:\n  - linter: fred\n    gvr: fred\n    tally:\n      ok: 1\n      info: 0\n      warning: 0\n      error: 0\n      score: 100\n    issues:\n      blee:\n      - group: __root__\n        gvr: fred\n        level: 0\n        message: Blah\n  errors:\n  - boom\nclustername: \"\"\ncontextname: \"\"\n"
 	summaryExp  = "\n\x1b[38;5;75mSUMMARY\x1b[0m\n\x1b[38;5;75m┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅┅\x1b[0m\n\x1b[38;5;122mYour cluster score: A (100)\n\x1b[0m                                                                                \x1b[38;5;82mo          .-'-.     \x1b[0m\n                                                                                \x1b[38;5;82m o     __| A    `\\  \x1b[0m\n                                                                                \x1b[38;5;82m  o   `-,-`--._   `\\\x1b[0m\n                                                                                \x1b[38;5;82m []  .->'  a     `|-'\x1b[0m\n                                                                                \x1b[38;5;82m  `=/ (__/_       /  \x1b[0m\n                                                                                \x1b[38;5;82m    \\_,    `    _)  \x1b[0m\n                                                                                \x1b[38;5;82m       `----;  |     \x1b[0m\n\n"
 	headerExp   = "\n\x1b[38;5;122m ___     ___ _____   _____ \x1b[0m                                                     \x1b[38;5;75mK          .-'-.     \x1b[0m\n\x1b[38;5;122m| _ \\___| _ \\ __\\ \\ / / __|\x1b[0m                                                     \x1b[38;5;75m 8     __|      `\\  \x1b[0m\n\x1b[38;5;122m|  _/ _ \\  _/ _| \\ V /| _| \x1b[0m                                                     \x1b[38;5;75m  s   `-,-`--._   `\\\x1b[0m\n\x1b[38;5;122m|_| \\___/_| |___| |_| |___|\x1b[0m                                                     \x1b[38;5;75m []  .->'  a     `|-'\x1b[0m\n\x1b[38;5;75m  Biffs`em and Buffs`em!\x1b[0m                                                        \x1b[38;5;75m  `=/ (__/_       /  \x1b[0m\n                                                                                \x1b[38;5;75m    \\_,    `    _)  \x1b[0m\n                                                                                \x1b[38;5;75m       `----;  |     \x1b[0m\n\n"
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report
+
+import (
+	"encoding/json"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/rules"
+)
+
+// CodeQualityIssue represents a GitLab Code Quality issue.
+type CodeQualityIssue struct {
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    CodeQualityLocation `json:"location"`
+}
+
+// CodeQualityLocation represents an issue location.
+type CodeQualityLocation struct {
+	Path  string           `json:"path"`
+	Lines CodeQualityLines `json:"lines"`
+}
+
+// CodeQualityLines represents an issue location lines.
+type CodeQualityLines struct {
+	Begin int `json:"begin"`
+}
+
+func codeQualityMarshal(b *Builder) ([]byte, error) {
+	ii := make([]CodeQualityIssue, 0)
+	b.Report.walk(func(s Section, fqn string, i issues.Issue) {
+		ii = append(ii, newCodeQualityIssue(s, fqn, i))
+	})
+
+	return json.MarshalIndent(ii, "", "  ")
+}
+
+func newCodeQualityIssue(s Section, fqn string, i issues.Issue) CodeQualityIssue {
+	check := s.Title
+	if code, ok := i.Code(); ok {
+		check = "POP-" + code
+	}
+	desc := i.Text()
+	if i.IsSubIssue() {
+		desc = i.Group + ": " + desc
+	}
+
+	return CodeQualityIssue{
+		Description: desc,
+		CheckName:   check,
+		Fingerprint: i.Fingerprint(s.GVR, fqn),
+		Severity:    toCodeQualitySeverity(i.Level),
+		Location: CodeQualityLocation{
+			Path:  s.GVR + "/" + fqn,
+			Lines: CodeQualityLines{Begin: 1},
+		},
+	}
+}
+
+func toCodeQualitySeverity(l rules.Level) string {
+	// nolint:exhaustive
+	switch l {
+	case rules.ErrorLevel:
+		return "critical"
+	case rules.WarnLevel:
+		return "major"
+	case rules.InfoLevel:
+		return "minor"
+	default:
+		return "info"
+	}
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/derailed/popeye/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilderCodeQuality(t *testing.T) {
+	b, ta := report.NewBuilder(), report.NewTally()
+	gvr := types.NewGVR("v1/pods")
+	o := issues.Outcome{
+		"default/p1": issues.Issues{
+			issues.New(gvr, issues.Root, rules.ErrorLevel, "[POP-100] Blah"),
+			issues.New(gvr, "c1", rules.WarnLevel, "[POP-101] Blee"),
+			issues.New(gvr, issues.Root, rules.InfoLevel, "Zorg"),
+		},
+		"default/p2": issues.Issues{},
+	}
+	ta.Rollup(o)
+	b.AddSection(gvr, "pod", rules.OkLevel, o, ta)
+	s, err := b.ToCodeQuality()
+	assert.Nil(t, err)
+
+	var ii []report.CodeQualityIssue
+	assert.Nil(t, json.Unmarshal([]byte(s), &ii))
+	assert.Len(t, ii, 3)
+
+	uu := []struct {
+		desc, check, severity string
+	}{
+		{"Blah", "POP-100", "critical"},
+		{"c1: Blee", "POP-101", "major"},
+		{"Zorg", "pods", "minor"},
+	}
+	for idx, u := range uu {
+		assert.Equal(t, u.desc, ii[idx].Description)
+		assert.Equal(t, u.check, ii[idx].CheckName)
+		assert.Equal(t, u.severity, ii[idx].Severity)
+		assert.Equal(t, "v1/pods/default/p1", ii[idx].Location.Path)
+		assert.Equal(t, 1, ii[idx].Location.Lines.Begin)
+		assert.Equal(t, o["default/p1"][idx].Fingerprint("v1/pods", "default/p1"), ii[idx].Fingerprint)
+	}
+	assert.NotEqual(t, ii[0].Fingerprint, ii[1].Fingerprint)
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+
+	"github.com/derailed/popeye/internal/issues"
+)
+
+var csvHeader = []string{
+	"cluster",
+	"context",
+	"linter",
+	"gvr",
+	"namespace",
+	"name",
+	"group",
+	"code",
+	"severity",
+	"message",
+}
+
+// csvMarshal flattens the report to one record per issue using the given
+// field delimiter.
+func csvMarshal(b *Builder, comma rune) ([]byte, error) {
+	var (
+		buff bytes.Buffer
+		w    = csv.NewWriter(&buff)
+	)
+	w.Comma = comma
+
+	if err := w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	var err error
+	b.Report.walk(func(s Section, fqn string, i issues.Issue) {
+		if err != nil {
+			return
+		}
+		err = w.Write(newRecord(b, s, fqn, i))
+	})
+	if err != nil {
+		return nil, err
+	}
+	w.Flush()
+
+	return buff.Bytes(), w.Error()
+}
+
+func newRecord(b *Builder, s Section, fqn string, i issues.Issue) []string {
+	ns, n := namespaced(fqn)
+	var group, code string
+	if i.IsSubIssue() {
+		group = i.Group
+	}
+	if c, ok := i.Code(); ok {
+		code = "POP-" + c
+	}
+
+	return []string{
+		b.ClusterName,
+		b.ContextName,
+		s.Title,
+		s.GVR,
+		ns,
+		n,
+		group,
+		code,
+		issues.LevelToStr(i.Level),
+		i.Text(),
+	}
+}
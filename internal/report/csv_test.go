@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report_test
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/derailed/popeye/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilderDelimited(t *testing.T) {
+	uu := map[string]struct {
+		fn func(*report.Builder) (string, error)
+		e  string
+	}{
+		"csv": {
+			fn: (*report.Builder).ToCSV,
+			e: "cluster,context,linter,gvr,namespace,name,group,code,severity,message\n" +
+				"c1,ctx1,pods,v1/pods,default,p1,,POP-100,error,\"Blah, blee\"\n" +
+				"c1,ctx1,pods,v1/pods,default,p1,c1,POP-101,warn,Blee\n" +
+				"c1,ctx1,nodes,v1/nodes,,n1,,,error,boom\n",
+		},
+		"tsv": {
+			fn: (*report.Builder).ToTSV,
+			e: "cluster\tcontext\tlinter\tgvr\tnamespace\tname\tgroup\tcode\tseverity\tmessage\n" +
+				"c1\tctx1\tpods\tv1/pods\tdefault\tp1\t\tPOP-100\terror\tBlah, blee\n" +
+				"c1\tctx1\tpods\tv1/pods\tdefault\tp1\tc1\tPOP-101\twarn\tBlee\n" +
+				"c1\tctx1\tnodes\tv1/nodes\t\tn1\t\t\terror\tboom\n",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			b := report.NewBuilder()
+			po := issues.Outcome{
+				"default/p1": issues.Issues{
+					issues.New(types.NewGVR("v1/pods"), issues.Root, rules.ErrorLevel, "[POP-100] Blah, blee"),
+					issues.New(types.NewGVR("v1/pods"), "c1", rules.WarnLevel, "[POP-101] Blee"),
+				},
+				"default/p2": issues.Issues{},
+			}
+			b.AddSection(types.NewGVR("v1/pods"), "pod", rules.OkLevel, po, report.NewTally().Rollup(po))
+			no := issues.Outcome{
+				"n1": issues.Issues{
+					issues.New(types.NewGVR("v1/nodes"), issues.Root, rules.ErrorLevel, "boom"),
+				},
+			}
+			b.AddSection(types.NewGVR("v1/nodes"), "node", rules.OkLevel, no, report.NewTally().Rollup(no))
+			b.ClusterName, b.ContextName = "c1", "ctx1"
+			s, err := u.fn(b)
+
+			assert.Nil(t, err)
+			assert.Equal(t, u.e, s)
+		})
+	}
+}
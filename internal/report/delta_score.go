@@ -32,6 +32,10 @@ func (s DeltaScore) changed() bool {
 	return s.s1 != s.s2
 }
 
+func (s DeltaScore) delta() int {
+	return s.s2 - s.s1
+}
+
 func (s DeltaScore) worst() bool {
 	if s.s1 == s.s2 {
 		return false
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/derailed/popeye/pkg/config"
+	"sigs.k8s.io/yaml"
+)
+
+// Violation represents a violated exit policy.
+type Violation struct {
+	Policy    string `json:"policy" yaml:"policy"`
+	Condition string `json:"condition" yaml:"condition"`
+	Value     int    `json:"value" yaml:"value"`
+	Baseline  *int   `json:"baseline,omitempty" yaml:"baseline,omitempty"`
+}
+
+// String returns a human readable violation.
+func (v Violation) String() string {
+	if v.Baseline != nil {
+		return fmt.Sprintf("%s: %s (%d vs %d)", v.Policy, v.Condition, v.Value, *v.Baseline)
+	}
+
+	return fmt.Sprintf("%s: %s (%d)", v.Policy, v.Condition, v.Value)
+}
+
+// LoadScan loads a structured json or yaml scan report.
+func LoadScan(path string) (*Scan, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Scan
+	if err := yaml.Unmarshal(raw, &s); err != nil {
+		return nil, fmt.Errorf("invalid scan report %s: %w", path, err)
+	}
+	if s.Version != ScanVersion {
+		return nil, fmt.Errorf("invalid scan report %s: expecting a %s json or yaml report", path, ScanVersion)
+	}
+
+	return &s, nil
+}
+
+// Count returns the number of issues matching an exit policy.
+func (s *Scan) Count(p config.ExitPolicy) int {
+	var n int
+	for _, section := range s.Sections {
+		for _, i := range section.Issues {
+			if p.Matches(i.Level, i.Code, i.Linter, i.Namespace) {
+				n++
+			}
+		}
+	}
+
+	return n
+}
+
+// Check returns the exit policies violated by the scan. Baseline
+// policies are checked against the given baseline scan.
+func (s *Scan) Check(pp config.ExitPolicies, baseline *Scan) []Violation {
+	var vv []Violation
+	for _, p := range pp {
+		v := Violation{Policy: p.Name, Condition: p.String(), Value: s.Count(p)}
+		limit := p.Max
+		if p.Baseline && baseline != nil {
+			b := baseline.Count(p)
+			v.Baseline, limit = &b, b+p.Max
+		}
+		if v.Value > limit {
+			vv = append(vv, v)
+		}
+	}
+
+	return vv
+}
+
+// SetViolations records the violated exit policies.
+func (b *Builder) SetViolations(vv []Violation) {
+	b.violations = vv
+}
+
+// Violations returns the violated exit policies.
+func (b *Builder) Violations() []Violation {
+	return b.violations
+}
+
+// PrintViolations prints out the violated exit policies.
+func (b *Builder) PrintViolations(s *ScanReport) {
+	if len(b.violations) == 0 {
+		return
+	}
+	s.Open("EXIT POLICIES", nil)
+	{
+		for _, v := range b.violations {
+			s.Print(rules.ErrorLevel, 1, v.String())
+		}
+	}
+	s.Close()
+}
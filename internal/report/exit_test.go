@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanCheck(t *testing.T) {
+	cur := &Scan{
+		Version: ScanVersion,
+		Sections: []ScanSection{
+			{
+				Linter: "pods",
+				Issues: []ScanIssue{
+					{Code: 102, Level: rules.ErrorLevel, Linter: "pods", Namespace: "prod-eu", Name: "p1"},
+					{Code: 102, Level: rules.ErrorLevel, Linter: "pods", Namespace: "dev", Name: "p2"},
+					{Code: 206, Level: rules.WarnLevel, Linter: "pods", Namespace: "dev", Name: "p2"},
+				},
+			},
+			{
+				Linter: "secrets",
+				Issues: []ScanIssue{
+					{Code: 400, Level: rules.InfoLevel, Linter: "secrets", Namespace: "dev", Name: "s1"},
+				},
+			},
+		},
+	}
+	baseline := &Scan{
+		Version: ScanVersion,
+		Sections: []ScanSection{
+			{
+				Linter: "pods",
+				Issues: []ScanIssue{
+					{Code: 102, Level: rules.ErrorLevel, Linter: "pods", Namespace: "dev", Name: "p2"},
+				},
+			},
+		},
+	}
+
+	one := 1
+	uu := map[string]struct {
+		pp config.ExitPolicies
+		e  []Violation
+	}{
+		"none": {
+			pp: config.ExitPolicies{{Name: "fatal", Codes: []rules.ID{1105}}},
+		},
+		"namespace": {
+			pp: config.ExitPolicies{{Name: "prod", Namespaces: []string{"prod-*"}}},
+			e: []Violation{
+				{Policy: "prod", Condition: "error level issues in namespaces prod-* exceeding 0", Value: 1},
+			},
+		},
+		"code": {
+			pp: config.ExitPolicies{{Name: "unused", Codes: []rules.ID{400}}},
+			e: []Violation{
+				{Policy: "unused", Condition: "issues with codes POP-400 exceeding 0", Value: 1},
+			},
+		},
+		"max": {
+			pp: config.ExitPolicies{{Name: "errors", Max: 2}},
+		},
+		"baseline": {
+			pp: config.ExitPolicies{{Name: "regression", Baseline: true}},
+			e: []Violation{
+				{Policy: "regression", Condition: "error level issues exceeding the baseline by more than 0", Value: 2, Baseline: &one},
+			},
+		},
+		"baseline-max": {
+			pp: config.ExitPolicies{{Name: "regression", Baseline: true, Max: 1}},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, cur.Check(u.pp, baseline))
+		})
+	}
+}
+
+func TestLoadScan(t *testing.T) {
+	dir := t.TempDir()
+	uu := map[string]struct {
+		raw string
+		e   *Scan
+		err string
+	}{
+		"json": {
+			raw: `{"version":"v2","report_time":"2024-01-01T00:00:00Z","score":90,"grade":"A","sections":[]}`,
+			e:   &Scan{Version: ScanVersion, ReportTime: "2024-01-01T00:00:00Z", Score: 90, Grade: "A", Sections: []ScanSection{}},
+		},
+		"yaml": {
+			raw: "version: v2\nscore: 80\ngrade: B\nsections:\n- linter: pods\n  tally: null\n  issues:\n  - code: 102\n    level: 3\n    linter: pods\n    name: p1\n",
+			e: &Scan{Version: ScanVersion, Score: 80, Grade: "B", Sections: []ScanSection{
+				{Linter: "pods", Issues: []ScanIssue{{Code: 102, Level: rules.ErrorLevel, Linter: "pods", Name: "p1"}}},
+			}},
+		},
+		"legacy": {
+			raw: `{"popeye":{"score":90}}`,
+			err: "expecting a v2 json or yaml report",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			path := filepath.Join(dir, k)
+			assert.NoError(t, os.WriteFile(path, []byte(u.raw), 0600))
+			s, err := LoadScan(path)
+			if u.err != "" {
+				assert.ErrorContains(t, err, u.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, u.e, s)
+		})
+	}
+}
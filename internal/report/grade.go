@@ -25,6 +25,11 @@ func Grade(score int) string {
 
 // Badge returns a popeye grade.
 func (s *ScanReport) Badge(score int) []string {
+	return s.GradeBadge(score, Grade(score))
+}
+
+// GradeBadge returns a popeye badge for a given score and grade.
+func (s *ScanReport) GradeBadge(score int, grade string) []string {
 	ic := make([]string, len(GraderLogo))
 	for i, l := range GraderLogo {
 		switch i {
@@ -33,7 +38,7 @@ func (s *ScanReport) Badge(score int) []string {
 				l = strings.Replace(l, "o", "S", 1)
 			}
 		case 1:
-			l = strings.Replace(l, "K", Grade(score), 1)
+			l = strings.Replace(l, "K", grade, 1)
 		case 3:
 			if score < 70 {
 				l = strings.Replace(l, "a", "O", 1)
@@ -6,6 +6,7 @@ package report
 import (
 	"encoding/xml"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -15,13 +16,14 @@ import (
 
 // TestSuites a collection of junit test suites.
 type TestSuites struct {
-	XMLName   xml.Name `xml:"testsuites"`
-	Name      string   `xml:"name,attr"`
-	Timestamp string   `xml:"report_time,attr"`
-	Tests     int      `xml:"tests,attr"`
-	Failures  int      `xml:"failures,attr"`
-	Errors    int      `xml:"errors,attr"`
-	Suites    []TestSuite
+	XMLName    xml.Name   `xml:"testsuites"`
+	Name       string     `xml:"name,attr"`
+	Timestamp  string     `xml:"report_time,attr"`
+	Tests      int        `xml:"tests,attr"`
+	Failures   int        `xml:"failures,attr"`
+	Errors     int        `xml:"errors,attr"`
+	Properties []Property `xml:"properties>property,omitempty"`
+	Suites     []TestSuite
 }
 
 // TestSuite represents a collection of tests
@@ -31,6 +33,7 @@ type TestSuite struct {
 	Tests      int        `xml:"tests,attr"`
 	Failures   int        `xml:"failures,attr"`
 	Errors     int        `xml:"errors,attr"`
+	Skipped    int        `xml:"skipped,attr,omitempty"`
 	Properties []Property `xml:"properties>property,omitempty"`
 	TestCases  []TestCase
 }
@@ -40,10 +43,17 @@ type TestCase struct {
 	XMLName   xml.Name `xml:"testcase"`
 	Classname string   `xml:"classname,attr"`
 	Name      string   `xml:"name,attr"`
+	Skipped   *Skipped
 	Failures  []Failure
 	Errors    []Error
 }
 
+// Skipped represents a skipped test.
+type Skipped struct {
+	XMLName xml.Name `xml:"skipped"`
+	Message string   `xml:"message,attr"`
+}
+
 // Property represents key/value pair.
 type Property struct {
 	Name  string `xml:"name,attr"`
@@ -55,6 +65,8 @@ type Failure struct {
 	XMLName xml.Name `xml:"failure"`
 	Message string   `xml:"message,attr"`
 	Type    string   `xml:"type,attr"`
+	Code    string   `xml:"code,attr,omitempty"`
+	Details string   `xml:",chardata"`
 }
 
 // Error represents a test error..
@@ -62,36 +74,61 @@ type Error struct {
 	XMLName xml.Name `xml:"error"`
 	Message string   `xml:"message,attr"`
 	Type    string   `xml:"type,attr"`
+	Code    string   `xml:"code,attr,omitempty"`
+	Details string   `xml:",chardata"`
 }
 
-func junitMarshal(b *Builder, level rules.Level) ([]byte, error) {
+func junitMarshal(b *Builder) ([]byte, error) {
 	s := TestSuites{
 		Name:      "Popeye",
 		Timestamp: b.Report.Timestamp,
 		Tests:     len(b.Report.Sections),
 		Errors:    len(b.Report.Errors),
+		Properties: []Property{
+			newProp("cluster", b.ClusterName),
+			newProp("context", b.ContextName),
+			newProp("score", strconv.Itoa(b.Report.Score)),
+			newProp("grade", b.Report.Grade),
+		},
 	}
 
 	for _, section := range b.Report.Sections {
-		s.Suites = append(s.Suites, newSuite(section, level))
+		s.Suites = append(s.Suites, newSuite(section))
 	}
 
 	return xml.MarshalIndent(s, "", "\t")
 }
 
-func newSuite(s Section, level rules.Level) TestSuite {
+func newSuite(s Section) TestSuite {
 	total, fails, errs := numTests(s.Outcome)
 	ts := TestSuite{
 		Name:     s.Title,
 		Tests:    total,
 		Failures: fails,
 		Errors:   errs,
+		Skipped:  len(s.skipped),
 	}
-	ts.Properties = tallyToProps(s.Tally, level)
+	ts.Properties = tallyToProps(s.Tally, s.level)
 
-	for k, v := range s.Outcome {
-		ts.TestCases = append(ts.TestCases, newTestCase(k, v))
+	kk := make([]string, 0, len(s.Outcome)+len(s.skipped))
+	for k := range s.Outcome {
+		kk = append(kk, k)
+	}
+	for _, k := range s.skipped {
+		if _, ok := s.Outcome[k]; !ok {
+			kk = append(kk, k)
+			ts.Tests++
+		}
+	}
+	slices.SortFunc(kk, issues.SortKeys)
+	for _, k := range kk {
+		tc := newTestCase(k, s.Outcome[k])
+		if slices.Contains(s.skipped, k) {
+			tc.Skipped = &Skipped{Message: "excluded by spinach configuration"}
+		}
+		ts.TestCases = append(ts.TestCases, tc)
 	}
+
 	return ts
 }
 
@@ -153,17 +190,41 @@ func namespaced(res string) (string, string) {
 }
 
 func newFailure(i issues.Issue) Failure {
+	code, msg, details := junitIssue(i)
 	return Failure{
-		Message: i.Message,
+		Message: msg,
 		Type:    issues.LevelToStr(i.Level),
+		Code:    code,
+		Details: details,
 	}
 }
 
 func newError(i issues.Issue) Error {
+	code, msg, details := junitIssue(i)
 	return Error{
-		Message: i.Message,
+		Message: msg,
 		Type:    issues.LevelToStr(i.Level),
+		Code:    code,
+		Details: details,
+	}
+}
+
+// junitIssue returns an issue code, message and a plain text summary.
+func junitIssue(i issues.Issue) (string, string, string) {
+	var code string
+	if c, ok := i.Code(); ok {
+		code = "POP-" + c
+	}
+	msg := i.Text()
+	if i.IsSubIssue() {
+		msg = i.Group + ": " + msg
 	}
+	details := fmt.Sprintf("severity: %s\nmessage: %s", issues.LevelToStr(i.Level), msg)
+	if code != "" {
+		details = "code: " + code + "\n" + details
+	}
+
+	return code, msg, details
 }
 
 func newProp(k, v string) Property {
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/rules"
+)
+
+var mdEscaper = strings.NewReplacer("<", "&lt;", ">", "&gt;", "|", "\\|")
+
+// markdownMarshal renders a compact report suitable for pull request comments.
+// At most max issues are listed. A max of zero lists all issues.
+func markdownMarshal(b *Builder, max int) []byte {
+	var w bytes.Buffer
+
+	if b.Report.Team != "" {
+		fmt.Fprintf(&w, "## Popeye Scan Report [%s]: %s (%d)\n\n", mdEscaper.Replace(b.Report.Team), b.Report.Grade, b.Report.Score)
+	} else {
+		fmt.Fprintf(&w, "## Popeye Scan Report: %s (%d)\n\n", b.Report.Grade, b.Report.Score)
+	}
+	fmt.Fprintf(&w, "**Cluster:** `%s` **Context:** `%s` **Time:** %s\n\n", orNA(b.ClusterName), orNA(b.ContextName), orNA(b.Report.Timestamp))
+
+	fmt.Fprintln(&w, "| Linter | Scanned | 💥 Error | 😱 Warn | 🔊 Info | ✅ OK | Score |")
+	fmt.Fprintln(&w, "|--------|--------:|---------:|--------:|--------:|-----:|------:|")
+	for _, s := range b.Report.Sections {
+		c := s.Tally.counts
+		fmt.Fprintf(&w, "| %s | %d | %d | %d | %d | %d | %d%% |\n", s.Title, len(s.Outcome), c[3], c[2], c[1], c[0], s.Tally.score)
+	}
+
+	var listed, omitted int
+	for _, s := range b.Report.Sections {
+		kk := make([]string, 0, len(s.Outcome))
+		for k, ii := range s.Outcome {
+			if ii.MaxSeverity() > rules.OkLevel {
+				kk = append(kk, k)
+			}
+		}
+		if len(kk) == 0 {
+			continue
+		}
+		if max > 0 && listed >= max {
+			for _, fqn := range kk {
+				omitted += countIssues(s.Outcome[fqn])
+			}
+			continue
+		}
+		slices.SortFunc(kk, issues.SortKeys)
+
+		fmt.Fprintf(&w, "\n<details>\n<summary><b>%s</b> (%d with issues)</summary>\n\n", s.Title, len(kk))
+		for _, fqn := range kk {
+			ii := s.Outcome[fqn]
+			if max > 0 && listed >= max {
+				omitted += countIssues(ii)
+				continue
+			}
+			fmt.Fprintf(&w, "- %s **%s**\n", EmojiForLevel(ii.MaxSeverity(), false), mdEscaper.Replace(fqn))
+			for _, i := range ii {
+				if i.Level == rules.OkLevel {
+					continue
+				}
+				if max > 0 && listed >= max {
+					omitted++
+					continue
+				}
+				listed++
+				fmt.Fprintf(&w, "  - %s %s\n", EmojiForLevel(i.Level, false), mdIssue(i))
+			}
+		}
+		fmt.Fprintln(&w, "\n</details>")
+	}
+	if omitted > 0 {
+		fmt.Fprintf(&w, "\n_%d more issue(s) omitted. Check the full report for details._\n", omitted)
+	}
+
+	return w.Bytes()
+}
+
+func mdIssue(i issues.Issue) string {
+	var s string
+	if i.IsSubIssue() {
+		s += "`" + i.Group + "` "
+	}
+	if code, ok := i.Code(); ok {
+		s += "**POP-" + code + "** "
+	}
+
+	return s + mdEscaper.Replace(i.Text())
+}
+
+func countIssues(ii issues.Issues) int {
+	var n int
+	for _, i := range ii {
+		if i.Level > rules.OkLevel {
+			n++
+		}
+	}
+
+	return n
+}
+
+func orNA(s string) string {
+	if s == "" {
+		return "n/a"
+	}
+
+	return s
+}
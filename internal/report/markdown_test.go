@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report_test
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/derailed/popeye/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilderMarkdown(t *testing.T) {
+	uu := map[string]struct {
+		max int
+		e   string
+	}{
+		"all": {
+			e: mdHeader + "- 💥 **default/p1**\n  - 💥 **POP-100** Blah &lt;none&gt;\n  - 😱 `c1` **POP-101** Blee\n- 🔊 **default/p3**\n  - 🔊 **POP-102** Zorg\n\n</details>\n",
+		},
+		"capped": {
+			max: 2,
+			e:   mdHeader + "- 💥 **default/p1**\n  - 💥 **POP-100** Blah &lt;none&gt;\n  - 😱 `c1` **POP-101** Blee\n\n</details>\n\n_1 more issue(s) omitted. Check the full report for details._\n",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			b, ta := report.NewBuilder(), report.NewTally()
+			gvr := types.NewGVR("v1/pods")
+			o := issues.Outcome{
+				"default/p1": issues.Issues{
+					issues.New(gvr, issues.Root, rules.ErrorLevel, "[POP-100] Blah <none>"),
+					issues.New(gvr, "c1", rules.WarnLevel, "[POP-101] Blee"),
+				},
+				"default/p2": issues.Issues{},
+				"default/p3": issues.Issues{
+					issues.New(gvr, issues.Root, rules.InfoLevel, "[POP-102] Zorg"),
+				},
+			}
+			ta.Rollup(o)
+			b.AddSection(gvr, "pod", rules.OkLevel, o, ta)
+			s, err := b.ToMarkdown(u.max)
+
+			assert.Nil(t, err)
+			assert.Equal(t, u.e, s)
+		})
+	}
+}
+
+func TestBuilderMarkdownCapSections(t *testing.T) {
+	b, ta := report.NewBuilder(), report.NewTally()
+	pgvr, ngvr := types.NewGVR("v1/pods"), types.NewGVR("v1/nodes")
+	po := issues.Outcome{
+		"default/p1": issues.Issues{
+			issues.New(pgvr, issues.Root, rules.ErrorLevel, "[POP-100] Blah"),
+		},
+	}
+	ta.Rollup(po)
+	b.AddSection(pgvr, "pod", rules.OkLevel, po, ta)
+
+	no, nta := issues.Outcome{
+		"n1": issues.Issues{
+			issues.New(ngvr, issues.Root, rules.WarnLevel, "[POP-700] Bozo"),
+			issues.New(ngvr, issues.Root, rules.InfoLevel, "[POP-701] Zorg"),
+		},
+	}, report.NewTally()
+	nta.Rollup(no)
+	b.AddSection(ngvr, "node", rules.OkLevel, no, nta)
+	s, err := b.ToMarkdown(1)
+
+	assert.Nil(t, err)
+	assert.Contains(t, s, "<summary><b>pods</b>")
+	assert.NotContains(t, s, "<summary><b>nodes</b>")
+	assert.Contains(t, s, "_2 more issue(s) omitted. Check the full report for details._")
+}
+
+const mdHeader = "## Popeye Scan Report: D (66)\n\n**Cluster:** `n/a` **Context:** `n/a` **Time:** n/a\n\n| Linter | Scanned | 💥 Error | 😱 Warn | 🔊 Info | ✅ OK | Score |\n|--------|--------:|---------:|--------:|--------:|-----:|------:|\n| pods | 3 | 1 | 0 | 1 | 1 | 66% |\n\n<details>\n<summary><b>pods</b> (2 with issues)</summary>\n\n"
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/rules"
+	"go.opentelemetry.io/otel/attribute"
+	otlog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// OTel attribute keys.
+const (
+	AttrLinter    = attribute.Key("popeye.linter")
+	AttrGVR       = attribute.Key("popeye.gvr")
+	AttrCode      = attribute.Key("popeye.code")
+	AttrSeverity  = attribute.Key("popeye.severity")
+	AttrGroup     = attribute.Key("popeye.group")
+	AttrGrade     = attribute.Key("popeye.grade")
+	AttrNamespace = attribute.Key("k8s.namespace.name")
+	AttrResource  = attribute.Key("popeye.resource")
+	AttrCluster   = attribute.Key("k8s.cluster.name")
+)
+
+type issueKey struct {
+	linter, ns, code string
+	level            rules.Level
+}
+
+// ToOTel emits each issue as a log record and the scan tallies as metrics.
+func (b *Builder) ToOTel(ctx context.Context, logger otlog.Logger, meter metric.Meter) error {
+	b.finalize()
+
+	now := time.Now()
+	counts := make(map[issueKey]int64)
+	b.Report.walk(func(s Section, fqn string, i issues.Issue) {
+		ns, n := namespaced(fqn)
+		code, _ := i.Code()
+		counts[issueKey{linter: s.Title, ns: ns, code: code, level: i.Level}]++
+
+		var r otlog.Record
+		r.SetTimestamp(now)
+		r.SetSeverity(toOTelSeverity(i.Level))
+		r.SetSeverityText(issues.LevelToStr(i.Level))
+		r.SetBody(otlog.StringValue(i.Text()))
+		r.AddAttributes(
+			otlog.String(string(AttrLinter), s.Title),
+			otlog.String(string(AttrGVR), s.GVR),
+			otlog.String(string(AttrCode), code),
+			otlog.String(string(AttrSeverity), issues.LevelToStr(i.Level)),
+			otlog.String(string(AttrNamespace), ns),
+			otlog.String(string(AttrResource), n),
+		)
+		if i.IsSubIssue() {
+			r.AddAttributes(otlog.String(string(AttrGroup), i.Group))
+		}
+		logger.Emit(ctx, r)
+	})
+
+	return b.otelCollect(ctx, meter, counts)
+}
+
+func (b *Builder) otelCollect(ctx context.Context, meter metric.Meter, counts map[issueKey]int64) error {
+	score, err := meter.Int64Gauge("popeye.cluster.score", metric.WithDescription("Popeye's scan cluster score."))
+	if err != nil {
+		return err
+	}
+	errs, err := meter.Int64Gauge("popeye.report.errors", metric.WithDescription("Popeye's scan errors total."))
+	if err != nil {
+		return err
+	}
+	tally, err := meter.Int64Gauge("popeye.linter.tally", metric.WithDescription("Popeye's linter tally totals."))
+	if err != nil {
+		return err
+	}
+	lscore, err := meter.Int64Gauge("popeye.linter.score", metric.WithDescription("Popeye's linter score."))
+	if err != nil {
+		return err
+	}
+	codes, err := meter.Int64Gauge("popeye.issues", metric.WithDescription("Popeye's report issues totals."))
+	if err != nil {
+		return err
+	}
+
+	cl := AttrCluster.String(b.ClusterName)
+	score.Record(ctx, int64(b.Report.Score), metric.WithAttributes(cl, AttrGrade.String(b.Report.Grade)))
+	errs.Record(ctx, int64(len(b.Report.Errors)), metric.WithAttributes(cl))
+	for _, s := range b.Report.Sections {
+		lscore.Record(ctx, int64(s.Tally.score), metric.WithAttributes(cl, AttrLinter.String(s.Title)))
+		for i, v := range s.Tally.counts {
+			tally.Record(ctx, int64(v), metric.WithAttributes(
+				cl,
+				AttrLinter.String(s.Title),
+				AttrSeverity.String(strings.ToLower(indexToTally(i))),
+			))
+		}
+	}
+	for k, v := range counts {
+		codes.Record(ctx, v, metric.WithAttributes(
+			cl,
+			AttrLinter.String(k.linter),
+			AttrNamespace.String(k.ns),
+			AttrCode.String(k.code),
+			AttrSeverity.String(issues.LevelToStr(k.level)),
+		))
+	}
+
+	return nil
+}
+
+func toOTelSeverity(l rules.Level) otlog.Severity {
+	// nolint:exhaustive
+	switch l {
+	case rules.ErrorLevel:
+		return otlog.SeverityError
+	case rules.WarnLevel:
+		return otlog.SeverityWarn
+	case rules.InfoLevel:
+		return otlog.SeverityInfo
+	default:
+		return otlog.SeverityDebug
+	}
+}
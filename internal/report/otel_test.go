@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/derailed/popeye/types"
+	"github.com/stretchr/testify/assert"
+	otlog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/embedded"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestBuilderOTel(t *testing.T) {
+	b, ta := report.NewBuilder(), report.NewTally()
+	gvr := types.NewGVR("v1/pods")
+	o := issues.Outcome{
+		"default/p1": issues.Issues{
+			issues.New(gvr, issues.Root, rules.ErrorLevel, "[POP-100] Blah"),
+			issues.New(gvr, "c1", rules.WarnLevel, "[POP-101] Blee"),
+		},
+		"default/p2": issues.Issues{},
+	}
+	ta.Rollup(o)
+	b.AddSection(gvr, "pod", rules.OkLevel, o, ta)
+	b.ClusterName = "c1"
+
+	var (
+		l      testLogger
+		reader = sdkmetric.NewManualReader()
+		meter  = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test")
+	)
+	assert.NoError(t, b.ToOTel(context.Background(), &l, meter))
+
+	assert.Len(t, l.records, 2)
+	r := l.records[1]
+	assert.Equal(t, "Blee", r.Body().AsString())
+	assert.Equal(t, otlog.SeverityWarn, r.Severity())
+	attrs := make(map[string]string)
+	r.WalkAttributes(func(kv otlog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value.AsString()
+		return true
+	})
+	assert.Equal(t, map[string]string{
+		"popeye.linter":      "pods",
+		"popeye.gvr":         "v1/pods",
+		"popeye.code":        "101",
+		"popeye.severity":    "warn",
+		"k8s.namespace.name": "default",
+		"popeye.resource":    "p1",
+		"popeye.group":       "c1",
+	}, attrs)
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+	mm := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			mm[m.Name] = m.Data
+		}
+	}
+	assert.Len(t, mm, 5)
+	score := mm["popeye.cluster.score"].(metricdata.Gauge[int64])
+	assert.Equal(t, int64(50), score.DataPoints[0].Value)
+	assert.Len(t, mm["popeye.issues"].(metricdata.Gauge[int64]).DataPoints, 2)
+}
+
+type testLogger struct {
+	embedded.Logger
+
+	records []otlog.Record
+}
+
+func (l *testLogger) Emit(_ context.Context, r otlog.Record) {
+	l.records = append(l.records, r)
+}
+
+func (*testLogger) Enabled(context.Context, otlog.EnabledParameters) bool {
+	return true
+}
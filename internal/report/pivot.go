@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report
+
+import (
+	"sort"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/rules"
+)
+
+const (
+	// GroupBySection groups issues by linter sections.
+	GroupBySection = "section"
+
+	// GroupByNamespace groups issues by resource namespace.
+	GroupByNamespace = "namespace"
+
+	// GroupByCode groups issues by issue code.
+	GroupByCode = "code"
+
+	// GroupByOwner groups issues by top level resource owner.
+	GroupByOwner = "owner"
+)
+
+const noCode = "n/a"
+
+// OwnerFunc resolves the top level owner of a given linter resource.
+type OwnerFunc func(gvr, fqn string) string
+
+// Pivot returns a new builder with the collected outcome regrouped by
+// namespace, code or owner. Resources are keyed by linter/fqn in the resulting
+// sections and each group gets its own tally. The cluster score is preserved.
+func (b *Builder) Pivot(by string, owner OwnerFunc) *Builder {
+	if by == "" || by == GroupBySection {
+		return b
+	}
+
+	pb := Builder{
+		ClusterName: b.ClusterName,
+		ContextName: b.ContextName,
+		scoring:     b.scoring,
+		violations:  b.violations,
+		provenance:  b.provenance,
+		stats:       b.stats,
+		teams:       b.teams,
+		Report: Report{
+			Timestamp:     b.Report.Timestamp,
+			GroupBy:       by,
+			Team:          b.Report.Team,
+			Errors:        b.Report.Errors,
+			sectionsCount: b.Report.sectionsCount,
+			totalScore:    b.Report.totalScore,
+			totalWeight:   b.Report.totalWeight,
+			scores:        b.Report.scores,
+		},
+	}
+
+	var (
+		oo      = make(map[string]issues.Outcome)
+		levels  = make(map[string]rules.Level)
+		sources = make(map[string]map[string]string)
+	)
+	add := func(group, key, gvr string, ii issues.Issues, level rules.Level) {
+		o, ok := oo[group]
+		if !ok {
+			o, levels[group], sources[group] = make(issues.Outcome), level, make(map[string]string)
+			oo[group] = o
+		}
+		o[key], sources[group][key] = append(o[key], ii...), gvr
+		if level < levels[group] {
+			levels[group] = level
+		}
+	}
+	for _, s := range b.Report.Sections {
+		for fqn, ii := range s.Outcome {
+			key := s.Title + "/" + fqn
+			switch by {
+			case GroupByNamespace:
+				add(namespaceOf(fqn), key, s.GVR, ii, s.level)
+			case GroupByOwner:
+				group := s.Title + "/" + fqn
+				if owner != nil {
+					group = owner(s.GVR, fqn)
+				}
+				add(group, key, s.GVR, ii, s.level)
+			case GroupByCode:
+				for _, i := range ii {
+					code := noCode
+					if c, ok := i.Code(); ok {
+						code = "POP-" + c
+					}
+					add(code, key, s.GVR, issues.Issues{i}, s.level)
+				}
+			}
+		}
+	}
+
+	for group, o := range oo {
+		pb.Report.Sections = append(pb.Report.Sections, Section{
+			Group:    group,
+			singular: group,
+			level:    levels[group],
+			Tally:    NewTally().Rollup(o).rescore(b.scoring),
+			Outcome:  o,
+			sources:  sources[group],
+		})
+	}
+	sort.Sort(pb.Report.Sections)
+
+	return &pb
+}
+
+func namespaceOf(fqn string) string {
+	ns, _ := client.Namespaced(fqn)
+	if ns == "" {
+		return client.ClusterScope
+	}
+
+	return ns
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report_test
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/derailed/popeye/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilderPivot(t *testing.T) {
+	uu := map[string]struct {
+		by string
+		e  string
+	}{
+		"section": {
+			by: report.GroupBySection,
+			e:  "popeye:\n  report_time: \"\"\n  score: 25\n  grade: F\n  sections:\n  - linter: pods\n    gvr: v1/pods\n    tally:\n      ok: 1\n      info: 0\n      warning: 0\n      error: 1\n      score: 50\n    issues:\n      default/p1:\n      - group: __root__\n        gvr: v1/pods\n        level: 3\n        message: '[POP-100] Blah'\n      - group: c1\n        gvr: v1/pods\n        level: 2\n        message: '[POP-101] Blee'\n  - linter: nodes\n    gvr: v1/nodes\n    tally:\n      ok: 0\n      info: 0\n      warning: 0\n      error: 1\n      score: 0\n    issues:\n      n1:\n      - group: __root__\n        gvr: v1/nodes\n        level: 3\n        message: boom\nclustername: \"\"\ncontextname: \"\"\n",
+		},
+		"namespace": {
+			by: report.GroupByNamespace,
+			e:  "popeye:\n  report_time: \"\"\n  score: 25\n  grade: F\n  group_by: namespace\n  sections:\n  - group: '-'\n    tally:\n      ok: 0\n      info: 0\n      warning: 0\n      error: 1\n      score: 0\n    issues:\n      nodes/n1:\n      - group: __root__\n        gvr: v1/nodes\n        level: 3\n        message: boom\n  - group: default\n    tally:\n      ok: 1\n      info: 0\n      warning: 0\n      error: 1\n      score: 50\n    issues:\n      pods/default/p1:\n      - group: __root__\n        gvr: v1/pods\n        level: 3\n        message: '[POP-100] Blah'\n      - group: c1\n        gvr: v1/pods\n        level: 2\n        message: '[POP-101] Blee'\nclustername: \"\"\ncontextname: \"\"\n",
+		},
+		"code": {
+			by: report.GroupByCode,
+			e:  "popeye:\n  report_time: \"\"\n  score: 25\n  grade: F\n  group_by: code\n  sections:\n  - group: POP-100\n    tally:\n      ok: 0\n      info: 0\n      warning: 0\n      error: 1\n      score: 0\n    issues:\n      pods/default/p1:\n      - group: __root__\n        gvr: v1/pods\n        level: 3\n        message: '[POP-100] Blah'\n  - group: POP-101\n    tally:\n      ok: 0\n      info: 0\n      warning: 1\n      error: 0\n      score: 0\n    issues:\n      pods/default/p1:\n      - group: c1\n        gvr: v1/pods\n        level: 2\n        message: '[POP-101] Blee'\n  - group: n/a\n    tally:\n      ok: 0\n      info: 0\n      warning: 0\n      error: 1\n      score: 0\n    issues:\n      nodes/n1:\n      - group: __root__\n        gvr: v1/nodes\n        level: 3\n        message: boom\nclustername: \"\"\ncontextname: \"\"\n",
+		},
+		"owner": {
+			by: report.GroupByOwner,
+			e:  "popeye:\n  report_time: \"\"\n  score: 25\n  grade: F\n  group_by: owner\n  sections:\n  - group: deployments/default/dp1\n    tally:\n      ok: 1\n      info: 0\n      warning: 0\n      error: 1\n      score: 50\n    issues:\n      pods/default/p1:\n      - group: __root__\n        gvr: v1/pods\n        level: 3\n        message: '[POP-100] Blah'\n      - group: c1\n        gvr: v1/pods\n        level: 2\n        message: '[POP-101] Blee'\n  - group: nodes/n1\n    tally:\n      ok: 0\n      info: 0\n      warning: 0\n      error: 1\n      score: 0\n    issues:\n      nodes/n1:\n      - group: __root__\n        gvr: v1/nodes\n        level: 3\n        message: boom\nclustername: \"\"\ncontextname: \"\"\n",
+		},
+	}
+
+	owner := func(gvr, fqn string) string {
+		if gvr == "v1/pods" {
+			return "deployments/default/dp1"
+		}
+		return types.NewGVR(gvr).R() + "/" + fqn
+	}
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			b := report.NewBuilder()
+			po := issues.Outcome{
+				"default/p1": issues.Issues{
+					issues.New(types.NewGVR("v1/pods"), issues.Root, rules.ErrorLevel, "[POP-100] Blah"),
+					issues.New(types.NewGVR("v1/pods"), "c1", rules.WarnLevel, "[POP-101] Blee"),
+				},
+				"default/p2": issues.Issues{},
+			}
+			b.AddSection(types.NewGVR("v1/pods"), "pod", rules.OkLevel, po, report.NewTally().Rollup(po))
+			no := issues.Outcome{
+				"n1": issues.Issues{
+					issues.New(types.NewGVR("v1/nodes"), issues.Root, rules.ErrorLevel, "boom"),
+				},
+			}
+			b.AddSection(types.NewGVR("v1/nodes"), "node", rules.OkLevel, no, report.NewTally().Rollup(no))
+			s, err := b.Pivot(u.by, owner).ToYAML()
+
+			assert.Nil(t, err)
+			assert.Equal(t, u.e, s)
+		})
+	}
+}
@@ -0,0 +1,248 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/derailed/popeye/types"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// PolicyReportGroupVersion represents the policy report API group version.
+	PolicyReportGroupVersion = "wgpolicyk8s.io/v1alpha2"
+
+	// PolicyReportKind represents a namespaced policy report.
+	PolicyReportKind = "PolicyReport"
+
+	// ClusterPolicyReportKind represents a cluster policy report.
+	ClusterPolicyReportKind = "ClusterPolicyReport"
+
+	policyReportName = "popeye"
+	policySource     = "popeye"
+	policyCategory   = "Popeye"
+	managedByLabel   = "app.kubernetes.io/managed-by"
+)
+
+// Policy report results.
+const (
+	PolicyPass  = "pass"
+	PolicyFail  = "fail"
+	PolicyWarn  = "warn"
+	PolicyError = "error"
+	PolicySkip  = "skip"
+)
+
+// PolicyReport represents a wgpolicyk8s.io PolicyReport or ClusterPolicyReport.
+type PolicyReport struct {
+	APIVersion string               `json:"apiVersion"`
+	Kind       string               `json:"kind"`
+	Metadata   PolicyReportMeta     `json:"metadata"`
+	Summary    PolicyReportSummary  `json:"summary"`
+	Results    []PolicyReportResult `json:"results"`
+}
+
+// PolicyReportMeta represents a policy report metadata.
+type PolicyReportMeta struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// PolicyReportSummary tallies a policy report results.
+type PolicyReportSummary struct {
+	Pass  int `json:"pass"`
+	Fail  int `json:"fail"`
+	Warn  int `json:"warn"`
+	Error int `json:"error"`
+	Skip  int `json:"skip"`
+}
+
+// PolicyReportResult represents a lint issue. Issue codes map to policies.
+type PolicyReportResult struct {
+	Source     string                 `json:"source"`
+	Policy     string                 `json:"policy"`
+	Rule       string                 `json:"rule,omitempty"`
+	Category   string                 `json:"category,omitempty"`
+	Severity   string                 `json:"severity,omitempty"`
+	Result     string                 `json:"result"`
+	Message    string                 `json:"message,omitempty"`
+	Scored     bool                   `json:"scored"`
+	Timestamp  *PolicyReportTimestamp `json:"timestamp,omitempty"`
+	Resources  []PolicyReportResource `json:"resources,omitempty"`
+	Properties map[string]string      `json:"properties,omitempty"`
+}
+
+// PolicyReportTimestamp represents a result time.
+type PolicyReportTimestamp struct {
+	Seconds int64 `json:"seconds"`
+	Nanos   int32 `json:"nanos"`
+}
+
+// PolicyReportResource represents an offending resource.
+type PolicyReportResource struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	UID        string `json:"uid,omitempty"`
+}
+
+// uidResolver resolves resource uids when supported by a resolver.
+type uidResolver interface {
+	UID(gvr, fqn string) string
+}
+
+// ToPolicyReports returns a PolicyReport per scanned namespace and a
+// ClusterPolicyReport for cluster scoped resources. Namespaces without issues
+// yield empty reports so previously applied findings get cleared.
+func (b *Builder) ToPolicyReports(r Resolver) []PolicyReport {
+	b.finalize()
+
+	var ts *PolicyReportTimestamp
+	if t, err := time.Parse(time.RFC3339, b.Report.Timestamp); err == nil {
+		ts = &PolicyReportTimestamp{Seconds: t.Unix()}
+	}
+	uids, _ := r.(uidResolver)
+	kinds := make(map[string]string)
+	reports := make(map[string]*PolicyReport)
+	for _, s := range b.Report.Sections {
+		kk := make([]string, 0, len(s.Outcome))
+		for k := range s.Outcome {
+			kk = append(kk, k)
+		}
+		slices.SortFunc(kk, issues.SortKeys)
+		for _, key := range kk {
+			linter, gvr, fqn := s.resource(key)
+			ns, n := client.Namespaced(fqn)
+			pr, ok := reports[ns]
+			if !ok {
+				pr = newPolicyReport(ns)
+				reports[ns] = pr
+			}
+			res := PolicyReportResource{
+				APIVersion: types.NewGVR(gvr).GV().String(),
+				Namespace:  ns,
+				Name:       n,
+			}
+			if r != nil {
+				if _, ok := kinds[gvr]; !ok {
+					kinds[gvr] = r.Kind(gvr)
+				}
+				res.Kind = kinds[gvr]
+			}
+			if uids != nil {
+				res.UID = uids.UID(gvr, fqn)
+			}
+			for _, i := range s.Outcome[key] {
+				pr.add(newPolicyResult(i, linter, gvr, res, ts))
+			}
+		}
+	}
+
+	nn := make([]string, 0, len(reports))
+	for ns := range reports {
+		nn = append(nn, ns)
+	}
+	slices.Sort(nn)
+	pp := make([]PolicyReport, 0, len(nn))
+	for _, ns := range nn {
+		pp = append(pp, *reports[ns])
+	}
+
+	return pp
+}
+
+// ToPolicyReportYAML dumps the policy reports as a multi documents YAML.
+func (b *Builder) ToPolicyReportYAML(r Resolver) (string, error) {
+	pp := b.ToPolicyReports(r)
+	docs := make([]string, 0, len(pp))
+	for _, p := range pp {
+		raw, err := yaml.Marshal(p)
+		if err != nil {
+			return "", err
+		}
+		docs = append(docs, "---\n"+string(raw))
+	}
+
+	return strings.Join(docs, ""), nil
+}
+
+func newPolicyReport(ns string) *PolicyReport {
+	kind := PolicyReportKind
+	if ns == "" {
+		kind = ClusterPolicyReportKind
+	}
+
+	return &PolicyReport{
+		APIVersion: PolicyReportGroupVersion,
+		Kind:       kind,
+		Metadata: PolicyReportMeta{
+			Name:      policyReportName,
+			Namespace: ns,
+			Labels:    map[string]string{managedByLabel: policySource},
+		},
+		Results: make([]PolicyReportResult, 0),
+	}
+}
+
+func (p *PolicyReport) add(r PolicyReportResult) {
+	switch r.Result {
+	case PolicyPass:
+		p.Summary.Pass++
+	case PolicyFail:
+		p.Summary.Fail++
+	case PolicyWarn:
+		p.Summary.Warn++
+	case PolicyError:
+		p.Summary.Error++
+	case PolicySkip:
+		p.Summary.Skip++
+	}
+	p.Results = append(p.Results, r)
+}
+
+func newPolicyResult(i issues.Issue, linter, gvr string, res PolicyReportResource, ts *PolicyReportTimestamp) PolicyReportResult {
+	r := PolicyReportResult{
+		Source:     policySource,
+		Policy:     policySource,
+		Rule:       linter,
+		Category:   policyCategory,
+		Message:    i.Text(),
+		Scored:     true,
+		Timestamp:  ts,
+		Resources:  []PolicyReportResource{res},
+		Properties: map[string]string{"gvr": gvr},
+	}
+	if code, ok := i.Code(); ok {
+		r.Policy = "POP-" + code
+	}
+	if i.GVR == containerGroup && i.IsSubIssue() {
+		r.Properties["container"] = i.Group
+	}
+	r.Result, r.Severity = toPolicyResult(i.Level)
+
+	return r
+}
+
+// toPolicyResult maps an issue level to a policy result and severity.
+func toPolicyResult(l rules.Level) (string, string) {
+	// nolint:exhaustive
+	switch l {
+	case rules.ErrorLevel:
+		return PolicyFail, "high"
+	case rules.WarnLevel:
+		return PolicyWarn, "medium"
+	case rules.InfoLevel:
+		return PolicyPass, "info"
+	default:
+		return PolicyPass, ""
+	}
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report_test
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/derailed/popeye/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type uidResolver struct {
+	fakeResolver
+}
+
+func (uidResolver) UID(gvr, fqn string) string {
+	return "uid-" + fqn
+}
+
+func TestBuilderPolicyReports(t *testing.T) {
+	b := report.NewBuilder()
+	po := issues.Outcome{
+		"default/p1": issues.Issues{
+			issues.New(types.NewGVR("v1/pods"), issues.Root, rules.ErrorLevel, "[POP-100] Blah p1").WithCode(100, "p1"),
+			issues.New(types.NewGVR("containers"), "c1", rules.WarnLevel, "[POP-101] Blee"),
+		},
+		"fred/p2": issues.Issues{},
+	}
+	b.AddSection(types.NewGVR("v1/pods"), "pod", rules.OkLevel, po, report.NewTally().Rollup(po))
+	no := issues.Outcome{
+		"n1": issues.Issues{
+			issues.New(types.NewGVR("v1/nodes"), issues.Root, rules.InfoLevel, "boom"),
+		},
+	}
+	b.AddSection(types.NewGVR("v1/nodes"), "node", rules.OkLevel, no, report.NewTally().Rollup(no))
+	b.Report.Timestamp = "2024-01-15T12:00:00Z"
+
+	pp := b.ToPolicyReports(uidResolver{})
+	assert.Len(t, pp, 3)
+
+	cpr := pp[0]
+	assert.Equal(t, report.ClusterPolicyReportKind, cpr.Kind)
+	assert.Equal(t, report.PolicyReportGroupVersion, cpr.APIVersion)
+	assert.Equal(t, report.PolicyReportMeta{
+		Name:   "popeye",
+		Labels: map[string]string{"app.kubernetes.io/managed-by": "popeye"},
+	}, cpr.Metadata)
+	assert.Equal(t, report.PolicyReportSummary{Pass: 1}, cpr.Summary)
+	assert.Equal(t, []report.PolicyReportResult{
+		{
+			Source:     "popeye",
+			Policy:     "popeye",
+			Rule:       "nodes",
+			Category:   "Popeye",
+			Severity:   "info",
+			Result:     report.PolicyPass,
+			Message:    "boom",
+			Scored:     true,
+			Timestamp:  &report.PolicyReportTimestamp{Seconds: 1705320000},
+			Resources:  []report.PolicyReportResource{{APIVersion: "v1", Kind: "Node", Name: "n1", UID: "uid-n1"}},
+			Properties: map[string]string{"gvr": "v1/nodes"},
+		},
+	}, cpr.Results)
+
+	pr := pp[1]
+	assert.Equal(t, report.PolicyReportKind, pr.Kind)
+	assert.Equal(t, "default", pr.Metadata.Namespace)
+	assert.Equal(t, report.PolicyReportSummary{Fail: 1, Warn: 1}, pr.Summary)
+	assert.Len(t, pr.Results, 2)
+	assert.Equal(t, "POP-100", pr.Results[0].Policy)
+	assert.Equal(t, "high", pr.Results[0].Severity)
+	assert.Equal(t, "Blah p1", pr.Results[0].Message)
+	assert.Equal(t, report.PolicyReportResource{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "p1", UID: "uid-default/p1"}, pr.Results[0].Resources[0])
+	assert.Equal(t, "POP-101", pr.Results[1].Policy)
+	assert.Equal(t, report.PolicyWarn, pr.Results[1].Result)
+	assert.Equal(t, "medium", pr.Results[1].Severity)
+	assert.Equal(t, map[string]string{"gvr": "v1/pods", "container": "c1"}, pr.Results[1].Properties)
+
+	empty := pp[2]
+	assert.Equal(t, "fred", empty.Metadata.Namespace)
+	assert.Equal(t, report.PolicyReportSummary{}, empty.Summary)
+	assert.Empty(t, empty.Results)
+}
+
+func TestBuilderPolicyReportYAML(t *testing.T) {
+	b := report.NewBuilder()
+	o := issues.Outcome{
+		"default/p1": issues.Issues{
+			issues.New(types.NewGVR("v1/pods"), issues.Root, rules.WarnLevel, "[POP-101] Blee"),
+		},
+	}
+	b.AddSection(types.NewGVR("v1/pods"), "pod", rules.OkLevel, o, report.NewTally().Rollup(o))
+
+	s, err := b.ToPolicyReportYAML(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `---
+apiVersion: wgpolicyk8s.io/v1alpha2
+kind: PolicyReport
+metadata:
+  labels:
+    app.kubernetes.io/managed-by: popeye
+  name: popeye
+  namespace: default
+results:
+- category: Popeye
+  message: Blee
+  policy: POP-101
+  properties:
+    gvr: v1/pods
+  resources:
+  - apiVersion: v1
+    name: p1
+    namespace: default
+  result: warn
+  rule: pods
+  scored: true
+  severity: medium
+  source: popeye
+summary:
+  error: 0
+  fail: 0
+  pass: 0
+  skip: 0
+  warn: 1
+`, s)
+}
@@ -26,6 +26,7 @@ var (
 		[]string{
 			"cluster",
 			"namespace",
+			"team",
 			"severity",
 		})
 
@@ -37,6 +38,7 @@ var (
 		[]string{
 			"cluster",
 			"namespace",
+			"team",
 			"linter",
 			"code",
 			"severity",
@@ -49,6 +51,7 @@ var (
 	},
 		[]string{
 			"cluster",
+			"team",
 			"linter",
 			"severity",
 		})
@@ -74,6 +77,18 @@ var (
 			"grade",
 		})
 
+	teamScoreGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "team_score",
+		Help:      "Popeye's scan team score.",
+	},
+		[]string{
+			"cluster",
+			"namespace",
+			"team",
+			"grade",
+		})
+
 	reportGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: namespace,
 		Name:      "report_score",
@@ -88,37 +103,48 @@ var (
 )
 
 func (b *Builder) promCollect(ns, scanReport string, codes rules.Glossary) {
-	cc := b.Report.Sections.CodeTallies()
-	cc.Compact()
-	cc.Dump()
-
 	cl := b.ClusterName
 	scoreGauge.WithLabelValues(cl, ns, b.Report.Grade).Set(float64(b.Report.Score))
 	reportGauge.WithLabelValues(cl, ns, b.Report.Grade, scanReport).Set(float64(b.Report.Score))
 	errGauge.WithLabelValues(cl, ns).Set(float64(len(b.Report.Errors)))
 
+	for _, tb := range b.Split() {
+		team := tb.Report.Team
+		if team != "" {
+			tb.finalize()
+			teamScoreGauge.WithLabelValues(cl, ns, team, tb.Report.Grade).Set(float64(tb.Report.Score))
+		}
+		tb.promCollectTallies(cl, team, codes)
+	}
+}
+
+func (b *Builder) promCollectTallies(cl, team string, codes rules.Glossary) {
+	cc := b.Report.Sections.CodeTallies()
+	cc.Compact()
+	cc.Dump()
+
 	for linter, nss := range cc {
 		for ns, st := range nss {
 			for level, count := range st.Rollup(codes) {
-				sevGauge.WithLabelValues(cl, ns, level.ToHumanLevel()).Add(float64(count))
+				sevGauge.WithLabelValues(cl, ns, team, level.ToHumanLevel()).Add(float64(count))
 			}
 			for code, count := range st {
 				cid, _ := strconv.Atoi(code)
 				c := codes[rules.ID(cid)]
-				codeGauge.WithLabelValues(cl, ns, linter, code, c.Severity.ToHumanLevel()).Add(float64(count))
+				codeGauge.WithLabelValues(cl, ns, team, linter, code, c.Severity.ToHumanLevel()).Add(float64(count))
 			}
 		}
 	}
 	for _, section := range b.Report.Sections {
 		for i, v := range section.Tally.counts {
-			linterGauge.WithLabelValues(cl, section.Title, strings.ToLower(indexToTally(i))).Add(float64(v))
+			linterGauge.WithLabelValues(cl, team, section.Title, strings.ToLower(indexToTally(i))).Add(float64(v))
 		}
 	}
 }
 
 func newPusher(gtwy *config.PushGateway, instance string) *push.Pusher {
 	registry := prometheus.NewRegistry()
-	registry.MustRegister(scoreGauge, errGauge, linterGauge, sevGauge, codeGauge, reportGauge)
+	registry.MustRegister(scoreGauge, teamScoreGauge, errGauge, linterGauge, sevGauge, codeGauge, reportGauge)
 
 	pusher := push.New(*gtwy.URL, "popeye").
 		Gatherer(registry).
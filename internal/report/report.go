@@ -6,10 +6,12 @@ package report
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/derailed/popeye/internal/issues"
 	"github.com/derailed/popeye/internal/issues/tally"
+	"github.com/derailed/popeye/internal/rules"
 	"github.com/fvbommel/sortorder"
 )
 
@@ -18,26 +20,85 @@ type Report struct {
 	Timestamp     string   `json:"report_time" yaml:"report_time"`
 	Score         int      `json:"score" yaml:"score"`
 	Grade         string   `json:"grade" yaml:"grade"`
+	GroupBy       string   `json:"group_by,omitempty" yaml:"group_by,omitempty"`
+	Team          string   `json:"team,omitempty" yaml:"team,omitempty"`
 	Sections      Sections `json:"sections,omitempty" yaml:"sections,omitempty"`
 	Errors        Errors   `json:"errors,omitempty" yaml:"errors,omitempty"`
 	sectionsCount int
-	totalScore    int
+	totalScore    float64
+	totalWeight   float64
+	scores        []LinterScore
 }
 
 func (r Report) ListSections() Sections {
 	return r.Sections
 }
 
+// resources lists all scanned resources per linter, including clean ones.
+func (r Report) resources() map[string][]string {
+	rr := make(map[string][]string, len(r.Sections))
+	for _, s := range r.Sections {
+		kk := make([]string, 0, len(s.Outcome))
+		for k := range s.Outcome {
+			kk = append(kk, k)
+		}
+		slices.SortFunc(kk, issues.SortKeys)
+		rr[s.name()] = kk
+	}
+
+	return rr
+}
+
+// walk visits all reported issues in section and resource order.
+func (r Report) walk(fn func(s Section, fqn string, i issues.Issue)) {
+	for _, s := range r.Sections {
+		kk := make([]string, 0, len(s.Outcome))
+		for k := range s.Outcome {
+			kk = append(kk, k)
+		}
+		slices.SortFunc(kk, issues.SortKeys)
+		for _, fqn := range kk {
+			for _, i := range s.Outcome[fqn] {
+				fn(s, fqn, i)
+			}
+		}
+	}
+}
+
 // Sections represents a collection of sections.
 type Sections []Section
 
 // Section represents a linter pass
 type Section struct {
-	Title    string         `json:"linter" yaml:"linter"`
-	GVR      string         `json:"gvr" yaml:"gvr"`
+	Title    string         `json:"linter,omitempty" yaml:"linter,omitempty"`
+	Group    string         `json:"group,omitempty" yaml:"group,omitempty"`
+	GVR      string         `json:"gvr,omitempty" yaml:"gvr,omitempty"`
 	Tally    *Tally         `json:"tally" yaml:"tally"`
 	Outcome  issues.Outcome `json:"issues,omitempty" yaml:"issues,omitempty"`
 	singular string
+	level    rules.Level
+	skipped  []string
+	sources  map[string]string
+}
+
+// name returns the section linter or pivot group name.
+func (s Section) name() string {
+	if s.Group != "" {
+		return s.Group
+	}
+
+	return s.Title
+}
+
+// resource returns the linter, gvr and fqn of a given outcome key. Pivoted
+// sections key resources by linter/fqn.
+func (s Section) resource(key string) (string, string, string) {
+	if s.Group == "" {
+		return s.Title, s.GVR, key
+	}
+	linter, fqn, _ := strings.Cut(key, "/")
+
+	return linter, s.sources[key], fqn
 }
 
 // Len returns the list size.
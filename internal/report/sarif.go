@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/rules"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	popeyeURI    = "https://popeyecli.io"
+)
+
+// SarifLog represents a SARIF 2.1.0 log.
+type SarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+// SarifRun represents a single scanner run.
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+// SarifTool represents the scanner.
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+// SarifDriver represents the scanner component and its rules.
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+// SarifRule represents a lint code.
+type SarifRule struct {
+	ID                   string               `json:"id"`
+	ShortDescription     SarifMessage         `json:"shortDescription"`
+	FullDescription      *SarifMessage        `json:"fullDescription,omitempty"`
+	Help                 *SarifMessage        `json:"help,omitempty"`
+	DefaultConfiguration SarifConfiguration   `json:"defaultConfiguration"`
+	Properties           *SarifRuleProperties `json:"properties,omitempty"`
+}
+
+// SarifRuleProperties tracks additional rule information.
+type SarifRuleProperties struct {
+	Tags []string `json:"tags,omitempty"`
+}
+
+// SarifConfiguration represents a rule default configuration.
+type SarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+// SarifMessage represents a plain text message.
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+// SarifResult represents a lint issue.
+type SarifResult struct {
+	RuleID     string                 `json:"ruleId,omitempty"`
+	RuleIndex  *int                   `json:"ruleIndex,omitempty"`
+	Level      string                 `json:"level"`
+	Message    SarifMessage           `json:"message"`
+	Locations  []SarifLocation        `json:"locations"`
+	Properties *SarifResultProperties `json:"properties,omitempty"`
+}
+
+// SarifResultProperties tracks additional result information.
+type SarifResultProperties struct {
+	// LintLevel the minimum issue level reported by the linter.
+	LintLevel string `json:"lintLevel"`
+}
+
+// SarifLocation represents an issue location.
+type SarifLocation struct {
+	LogicalLocations []SarifLogicalLocation `json:"logicalLocations"`
+}
+
+// SarifLogicalLocation represents a Kubernetes resource or container.
+type SarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+func sarifMarshal(b *Builder, cc rules.Glossary) ([]byte, error) {
+	ids := make([]rules.ID, 0, len(cc))
+	for id := range cc {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	driver := SarifDriver{
+		Name:           "popeye",
+		InformationURI: popeyeURI,
+		Rules:          make([]SarifRule, 0, len(ids)),
+	}
+	index := make(map[string]int, len(ids))
+	for i, id := range ids {
+		driver.Rules = append(driver.Rules, newSarifRule(id, cc[id]))
+		index[id.String()] = i
+	}
+
+	run := SarifRun{
+		Tool:    SarifTool{Driver: driver},
+		Results: make([]SarifResult, 0),
+	}
+	b.Report.walk(func(s Section, fqn string, i issues.Issue) {
+		run.Results = append(run.Results, newSarifResult(s, fqn, i, index))
+	})
+
+	return json.MarshalIndent(SarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []SarifRun{run},
+	}, "", "  ")
+}
+
+func newSarifRule(id rules.ID, c *rules.Code) SarifRule {
+	r := SarifRule{
+		ID:                   "POP-" + id.String(),
+		ShortDescription:     SarifMessage{Text: c.Message},
+		DefaultConfiguration: SarifConfiguration{Level: toSarifLevel(c.Severity)},
+	}
+	if c.Description != "" {
+		r.FullDescription = &SarifMessage{Text: c.Description}
+	}
+	if c.Remediation != "" {
+		r.Help = &SarifMessage{Text: c.Remediation}
+	}
+	if len(c.Linters) > 0 {
+		r.Properties = &SarifRuleProperties{Tags: c.Linters}
+	}
+
+	return r
+}
+
+func newSarifResult(s Section, fqn string, i issues.Issue, index map[string]int) SarifResult {
+	r := SarifResult{
+		Level:      toSarifLevel(i.Level),
+		Message:    SarifMessage{Text: i.Text()},
+		Locations:  []SarifLocation{{LogicalLocations: []SarifLogicalLocation{newSarifLocation(s, fqn, i)}}},
+		Properties: &SarifResultProperties{LintLevel: s.level.ToHumanLevel()},
+	}
+	if code, ok := i.Code(); ok {
+		r.RuleID = "POP-" + code
+		if idx, ok := index[code]; ok {
+			r.RuleIndex = &idx
+		}
+	}
+
+	return r
+}
+
+func newSarifLocation(s Section, fqn string, i issues.Issue) SarifLogicalLocation {
+	_, n := namespaced(fqn)
+	loc := SarifLogicalLocation{
+		Name:               n,
+		FullyQualifiedName: strings.Join([]string{s.GVR, fqn}, "/"),
+		Kind:               "resource",
+	}
+	if i.IsSubIssue() {
+		loc.FullyQualifiedName += "/" + i.Group
+	}
+
+	return loc
+}
+
+func toSarifLevel(l rules.Level) string {
+	// nolint:exhaustive
+	switch l {
+	case rules.ErrorLevel:
+		return "error"
+	case rules.WarnLevel:
+		return "warning"
+	case rules.InfoLevel:
+		return "note"
+	default:
+		return "none"
+	}
+}
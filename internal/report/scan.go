@@ -0,0 +1,208 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/rules"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// ScanVersion represents the structured report version.
+	ScanVersion = "v2"
+
+	containerGroup = "containers"
+)
+
+// Resolver resolves resource metadata not tracked by the linters.
+type Resolver interface {
+	// Kind returns the kind of a given resource.
+	Kind(gvr string) string
+
+	// Controller returns the controller of a given resource if any.
+	Controller(gvr, fqn string) *OwnerRef
+}
+
+// Scan represents a structured scan report.
+type Scan struct {
+	Version    string          `json:"version" yaml:"version"`
+	ReportTime string          `json:"report_time" yaml:"report_time"`
+	Cluster    string          `json:"cluster,omitempty" yaml:"cluster,omitempty"`
+	Context    string          `json:"context,omitempty" yaml:"context,omitempty"`
+	Score      int             `json:"score" yaml:"score"`
+	Grade      string          `json:"grade" yaml:"grade"`
+	GroupBy    string          `json:"group_by,omitempty" yaml:"group_by,omitempty"`
+	Team       string          `json:"team,omitempty" yaml:"team,omitempty"`
+	Sections   []ScanSection   `json:"sections" yaml:"sections"`
+	Errors     []string        `json:"errors,omitempty" yaml:"errors,omitempty"`
+	Breakdown  *ScoreBreakdown `json:"score_breakdown,omitempty" yaml:"score_breakdown,omitempty"`
+	Violations []Violation     `json:"violations,omitempty" yaml:"violations,omitempty"`
+	Provenance *Provenance     `json:"provenance,omitempty" yaml:"provenance,omitempty"`
+	Stats      *Stats          `json:"stats,omitempty" yaml:"stats,omitempty"`
+}
+
+// ScanSection represents a linter section or a pivot group.
+type ScanSection struct {
+	Linter string      `json:"linter,omitempty" yaml:"linter,omitempty"`
+	Group  string      `json:"group,omitempty" yaml:"group,omitempty"`
+	GVR    string      `json:"gvr,omitempty" yaml:"gvr,omitempty"`
+	Level  string      `json:"level" yaml:"level"`
+	Tally  *Tally      `json:"tally" yaml:"tally"`
+	Issues []ScanIssue `json:"issues" yaml:"issues"`
+}
+
+// ScanIssue represents a resource issue.
+type ScanIssue struct {
+	Code      rules.ID    `json:"code,omitempty" yaml:"code,omitempty"`
+	Severity  string      `json:"severity" yaml:"severity"`
+	Level     rules.Level `json:"level" yaml:"level"`
+	Message   string      `json:"message" yaml:"message"`
+	Args      []string    `json:"args,omitempty" yaml:"args,omitempty"`
+	Linter    string      `json:"linter" yaml:"linter"`
+	GVR       string      `json:"gvr" yaml:"gvr"`
+	Kind      string      `json:"kind,omitempty" yaml:"kind,omitempty"`
+	Namespace string      `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Name      string      `json:"name" yaml:"name"`
+	Container string      `json:"container,omitempty" yaml:"container,omitempty"`
+	Owner     *OwnerRef   `json:"owner,omitempty" yaml:"owner,omitempty"`
+	Team      string      `json:"team,omitempty" yaml:"team,omitempty"`
+	FirstSeen string      `json:"first_seen,omitempty" yaml:"first_seen,omitempty"`
+	OpenDays  *int        `json:"open_days,omitempty" yaml:"open_days,omitempty"`
+}
+
+// OwnerRef represents a resource controller.
+type OwnerRef struct {
+	APIVersion string `json:"apiVersion" yaml:"apiVersion"`
+	Kind       string `json:"kind" yaml:"kind"`
+	Name       string `json:"name" yaml:"name"`
+}
+
+// ToScan returns the structured scan report. Resource kinds and owners are
+// only reported when a resolver is given.
+func (b *Builder) ToScan(r Resolver) *Scan {
+	b.finalize()
+
+	s := Scan{
+		Version:    ScanVersion,
+		ReportTime: b.Report.Timestamp,
+		Cluster:    b.ClusterName,
+		Context:    b.ContextName,
+		Score:      b.Report.Score,
+		Grade:      b.Report.Grade,
+		GroupBy:    b.Report.GroupBy,
+		Team:       b.Report.Team,
+		Sections:   make([]ScanSection, 0, len(b.Report.Sections)),
+		Breakdown:  b.Breakdown(),
+		Violations: b.violations,
+		Provenance: b.provenance,
+		Stats:      b.Stats(),
+	}
+	for _, e := range b.Report.Errors {
+		if e != nil {
+			s.Errors = append(s.Errors, e.Error())
+		}
+	}
+
+	now, err := time.Parse(time.RFC3339, b.Report.Timestamp)
+	if err != nil {
+		now = time.Now()
+	}
+	kinds := make(map[string]string)
+	for _, section := range b.Report.Sections {
+		ss := ScanSection{
+			Linter: section.Title,
+			Group:  section.Group,
+			GVR:    section.GVR,
+			Level:  section.level.ToHumanLevel(),
+			Tally:  section.Tally,
+			Issues: make([]ScanIssue, 0),
+		}
+		kk := make([]string, 0, len(section.Outcome))
+		for k := range section.Outcome {
+			kk = append(kk, k)
+		}
+		slices.SortFunc(kk, issues.SortKeys)
+		for _, key := range kk {
+			ii := section.Outcome[key]
+			if len(ii) == 0 {
+				continue
+			}
+			linter, gvr, fqn := section.resource(key)
+			ns, n := client.Namespaced(fqn)
+			var owner *OwnerRef
+			if r != nil {
+				if _, ok := kinds[gvr]; !ok {
+					kinds[gvr] = r.Kind(gvr)
+				}
+				owner = r.Controller(gvr, fqn)
+			}
+			team := b.Team(gvr, fqn)
+			for _, i := range ii {
+				si := newScanIssue(i, linter, gvr, kinds[gvr], ns, n, owner)
+				si.Team = team
+				if days, ok := i.OpenDays(now); ok {
+					si.FirstSeen, si.OpenDays = i.FirstSeen.UTC().Format(time.RFC3339), &days
+				}
+				ss.Issues = append(ss.Issues, si)
+			}
+		}
+		s.Sections = append(s.Sections, ss)
+	}
+
+	return &s
+}
+
+// ToScanJSON dumps the structured scan report to JSON.
+func (b *Builder) ToScanJSON(r Resolver) (string, error) {
+	raw, err := json.Marshal(b.ToScan(r))
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
+// ToScanYAML dumps the structured scan report to YAML.
+func (b *Builder) ToScanYAML(r Resolver) (string, error) {
+	raw, err := yaml.Marshal(b.ToScan(r))
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
+func newScanIssue(i issues.Issue, linter, gvr, kind, ns, n string, owner *OwnerRef) ScanIssue {
+	si := ScanIssue{
+		Code:      i.ID,
+		Severity:  issues.LevelToStr(i.Level),
+		Level:     i.Level,
+		Message:   i.Text(),
+		Args:      i.Args,
+		Linter:    linter,
+		GVR:       gvr,
+		Kind:      kind,
+		Namespace: ns,
+		Name:      n,
+		Owner:     owner,
+	}
+	if si.Code == 0 {
+		if c, ok := i.Code(); ok {
+			id, _ := strconv.Atoi(c)
+			si.Code = rules.ID(id)
+		}
+	}
+	if i.GVR == containerGroup && i.IsSubIssue() {
+		si.Container = i.Group
+	}
+
+	return si
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/derailed/popeye/pkg/config/json"
+	"github.com/derailed/popeye/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilderScan(t *testing.T) {
+	uu := map[string]struct {
+		by string
+		r  report.Resolver
+		e  string
+	}{
+		"plain": {
+			e: `{"version":"v2","report_time":"","cluster":"c1","context":"ctx1","score":25,"grade":"F","sections":[{"linter":"pods","gvr":"v1/pods","level":"ok","tally":{"ok":1,"info":0,"warning":0,"error":1,"score":50},"issues":[{"code":100,"severity":"error","level":3,"message":"Blah p1","args":["p1"],"linter":"pods","gvr":"v1/pods","namespace":"default","name":"p1"},{"code":101,"severity":"warn","level":2,"message":"Blee","linter":"pods","gvr":"v1/pods","namespace":"default","name":"p1","container":"c1"}]},{"linter":"nodes","gvr":"v1/nodes","level":"ok","tally":{"ok":0,"info":0,"warning":0,"error":1,"score":0},"issues":[{"severity":"error","level":3,"message":"boom","linter":"nodes","gvr":"v1/nodes","name":"n1"}]}],"errors":["bozo"],"score_breakdown":{"penalties":{"error":1,"info":0,"ok":0,"warn":1},"grades":{"A":90,"B":80,"C":70,"D":60,"E":50},"include_empty":true,"total_weight":2,"score":25,"grade":"F","linters":[{"linter":"nodes","resources":1,"penalty":1,"score":0,"weight":1,"contribution":0,"counted":true},{"linter":"pods","resources":2,"penalty":1,"score":50,"weight":1,"contribution":25,"counted":true}]}}`,
+		},
+		"resolver": {
+			r: fakeResolver{},
+			e: `{"version":"v2","report_time":"","cluster":"c1","context":"ctx1","score":25,"grade":"F","sections":[{"linter":"pods","gvr":"v1/pods","level":"ok","tally":{"ok":1,"info":0,"warning":0,"error":1,"score":50},"issues":[{"code":100,"severity":"error","level":3,"message":"Blah p1","args":["p1"],"linter":"pods","gvr":"v1/pods","kind":"Pod","namespace":"default","name":"p1","owner":{"apiVersion":"apps/v1","kind":"ReplicaSet","name":"rs1"}},{"code":101,"severity":"warn","level":2,"message":"Blee","linter":"pods","gvr":"v1/pods","kind":"Pod","namespace":"default","name":"p1","container":"c1","owner":{"apiVersion":"apps/v1","kind":"ReplicaSet","name":"rs1"}}]},{"linter":"nodes","gvr":"v1/nodes","level":"ok","tally":{"ok":0,"info":0,"warning":0,"error":1,"score":0},"issues":[{"severity":"error","level":3,"message":"boom","linter":"nodes","gvr":"v1/nodes","kind":"Node","name":"n1"}]}],"errors":["bozo"],"score_breakdown":{"penalties":{"error":1,"info":0,"ok":0,"warn":1},"grades":{"A":90,"B":80,"C":70,"D":60,"E":50},"include_empty":true,"total_weight":2,"score":25,"grade":"F","linters":[{"linter":"nodes","resources":1,"penalty":1,"score":0,"weight":1,"contribution":0,"counted":true},{"linter":"pods","resources":2,"penalty":1,"score":50,"weight":1,"contribution":25,"counted":true}]}}`,
+		},
+		"pivot": {
+			by: report.GroupByNamespace,
+			r:  fakeResolver{},
+			e:  `{"version":"v2","report_time":"","cluster":"c1","context":"ctx1","score":25,"grade":"F","group_by":"namespace","sections":[{"group":"-","level":"ok","tally":{"ok":0,"info":0,"warning":0,"error":1,"score":0},"issues":[{"severity":"error","level":3,"message":"boom","linter":"nodes","gvr":"v1/nodes","kind":"Node","name":"n1"}]},{"group":"default","level":"ok","tally":{"ok":1,"info":0,"warning":0,"error":1,"score":50},"issues":[{"code":100,"severity":"error","level":3,"message":"Blah p1","args":["p1"],"linter":"pods","gvr":"v1/pods","kind":"Pod","namespace":"default","name":"p1","owner":{"apiVersion":"apps/v1","kind":"ReplicaSet","name":"rs1"}},{"code":101,"severity":"warn","level":2,"message":"Blee","linter":"pods","gvr":"v1/pods","kind":"Pod","namespace":"default","name":"p1","container":"c1","owner":{"apiVersion":"apps/v1","kind":"ReplicaSet","name":"rs1"}}]}],"errors":["bozo"],"score_breakdown":{"penalties":{"error":1,"info":0,"ok":0,"warn":1},"grades":{"A":90,"B":80,"C":70,"D":60,"E":50},"include_empty":true,"total_weight":2,"score":25,"grade":"F","linters":[{"linter":"nodes","resources":1,"penalty":1,"score":0,"weight":1,"contribution":0,"counted":true},{"linter":"pods","resources":2,"penalty":1,"score":50,"weight":1,"contribution":25,"counted":true}]}}`,
+		},
+	}
+
+	v := json.NewValidator()
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			b := report.NewBuilder()
+			po := issues.Outcome{
+				"default/p1": issues.Issues{
+					issues.New(types.NewGVR("v1/pods"), issues.Root, rules.ErrorLevel, "[POP-100] Blah p1").WithCode(100, "p1"),
+					issues.New(types.NewGVR("containers"), "c1", rules.WarnLevel, "[POP-101] Blee"),
+				},
+				"default/p2": issues.Issues{},
+			}
+			b.AddSection(types.NewGVR("v1/pods"), "pod", rules.OkLevel, po, report.NewTally().Rollup(po))
+			no := issues.Outcome{
+				"n1": issues.Issues{
+					issues.New(types.NewGVR("v1/nodes"), issues.Root, rules.ErrorLevel, "boom"),
+				},
+			}
+			b.AddSection(types.NewGVR("v1/nodes"), "node", rules.OkLevel, no, report.NewTally().Rollup(no))
+			b.AddError(errors.New("bozo"))
+			b.ClusterName, b.ContextName = "c1", "ctx1"
+			s, err := b.Pivot(u.by, nil).ToScanJSON(u.r)
+
+			assert.Nil(t, err)
+			assert.Equal(t, u.e, s)
+			assert.NoError(t, v.Validate(json.ReportSchema, []byte(s)))
+		})
+	}
+}
+
+type fakeResolver struct{}
+
+func (fakeResolver) Kind(gvr string) string {
+	switch gvr {
+	case "v1/pods":
+		return "Pod"
+	case "v1/nodes":
+		return "Node"
+	default:
+		return ""
+	}
+}
+
+func (fakeResolver) Controller(gvr, fqn string) *report.OwnerRef {
+	if fqn != "default/p1" {
+		return nil
+	}
+
+	return &report.OwnerRef{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "rs1"}
+}
+
+func TestBuilderScanAge(t *testing.T) {
+	fs := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	i := issues.New(types.NewGVR("v1/pods"), issues.Root, rules.ErrorLevel, "[POP-100] boom")
+	i.FirstSeen = &fs
+	o := issues.Outcome{
+		"default/p1": issues.Issues{
+			i,
+			issues.New(types.NewGVR("v1/pods"), issues.Root, rules.WarnLevel, "[POP-101] blee"),
+		},
+	}
+	b := report.NewBuilder()
+	b.AddSection(types.NewGVR("v1/pods"), "pod", rules.OkLevel, o, report.NewTally().Rollup(o))
+	b.Report.Timestamp = "2024-01-15T12:00:00Z"
+
+	s := b.ToScan(nil)
+	ii := s.Sections[0].Issues
+	assert.Equal(t, "2024-01-01T00:00:00Z", ii[0].FirstSeen)
+	assert.Equal(t, 14, *ii[0].OpenDays)
+	assert.Empty(t, ii[1].FirstSeen)
+	assert.Nil(t, ii[1].OpenDays)
+
+	raw, err := b.ToScanJSON(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, json.NewValidator().Validate(json.ReportSchema, []byte(raw)))
+}
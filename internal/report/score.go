@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/derailed/popeye/pkg/config"
+)
+
+// ScoreBreakdown explains how the cluster score was computed. The cluster
+// score is the weighted average of the counted linter scores. A linter score is
+// the percentage of resources left once each resource is penalized based on
+// its highest issue severity.
+type ScoreBreakdown struct {
+	Penalties    map[string]float64 `json:"penalties" yaml:"penalties"`
+	Grades       map[string]int     `json:"grades" yaml:"grades"`
+	IncludeEmpty bool               `json:"include_empty" yaml:"include_empty"`
+	TotalWeight  float64            `json:"total_weight" yaml:"total_weight"`
+	Score        int                `json:"score" yaml:"score"`
+	Grade        string             `json:"grade" yaml:"grade"`
+	Linters      []LinterScore      `json:"linters" yaml:"linters"`
+}
+
+// LinterScore tracks a linter contribution to the cluster score.
+type LinterScore struct {
+	Linter       string  `json:"linter" yaml:"linter"`
+	Resources    int     `json:"resources" yaml:"resources"`
+	Penalty      float64 `json:"penalty" yaml:"penalty"`
+	Score        int     `json:"score" yaml:"score"`
+	Weight       float64 `json:"weight" yaml:"weight"`
+	Contribution float64 `json:"contribution" yaml:"contribution"`
+	Counted      bool    `json:"counted" yaml:"counted"`
+}
+
+// SetScoring sets the scoring model used to compute linter and cluster scores.
+func (b *Builder) SetScoring(s config.Scoring) {
+	b.scoring = s
+}
+
+// Breakdown returns how the cluster score was reached.
+func (b *Builder) Breakdown() *ScoreBreakdown {
+	b.finalize()
+
+	bd := ScoreBreakdown{
+		Penalties:    b.scoring.Penalties(),
+		Grades:       b.scoring.GradeCutoffs(),
+		IncludeEmpty: b.scoring.CountEmpty(),
+		TotalWeight:  b.Report.totalWeight,
+		Score:        b.Report.Score,
+		Grade:        b.Report.Grade,
+		Linters:      make([]LinterScore, 0, len(b.Report.scores)),
+	}
+	for _, ls := range b.Report.scores {
+		if ls.Counted && bd.TotalWeight > 0 {
+			ls.Contribution = ls.Weight * float64(ls.Score) / bd.TotalWeight
+		}
+		bd.Linters = append(bd.Linters, ls)
+	}
+	slices.SortFunc(bd.Linters, func(a, b LinterScore) int {
+		return strings.Compare(a.Linter, b.Linter)
+	})
+
+	return &bd
+}
+
+// score records a linter section contribution to the cluster score.
+func (b *Builder) score(linter string, t *Tally) {
+	t.rescore(b.scoring)
+	ls := LinterScore{
+		Linter:    linter,
+		Resources: t.total(),
+		Penalty:   t.penalty,
+		Score:     t.Score(),
+		Weight:    b.scoring.Weight(linter),
+	}
+	ls.Counted = ls.Resources > 0 || b.scoring.CountEmpty()
+	if ls.Counted {
+		b.Report.totalScore += ls.Weight * float64(ls.Score)
+		b.Report.totalWeight += ls.Weight
+	}
+	b.Report.scores = append(b.Report.scores, ls)
+}
+
+// gradeFor returns a grade given the minimum score for each grade.
+func gradeFor(score int, cutoffs map[string]int) string {
+	gg := make([]string, 0, len(cutoffs))
+	for g := range cutoffs {
+		gg = append(gg, g)
+	}
+	slices.Sort(gg)
+	for _, g := range gg {
+		if score >= cutoffs[g] {
+			return g
+		}
+	}
+
+	return "F"
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report_test
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/derailed/popeye/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilderBreakdown(t *testing.T) {
+	no := false
+	uu := map[string]struct {
+		sc    config.Scoring
+		score int
+		grade string
+		ls    []report.LinterScore
+	}{
+		"default": {
+			score: 50,
+			grade: "E",
+			ls: []report.LinterScore{
+				{Linter: "namespaces", Score: 100, Weight: 1, Contribution: 100.0 / 3, Counted: true},
+				{Linter: "pods", Resources: 4, Penalty: 2, Score: 50, Weight: 1, Contribution: 50.0 / 3, Counted: true},
+				{Linter: "secrets", Resources: 1, Penalty: 1, Score: 0, Weight: 1, Contribution: 0, Counted: true},
+			},
+		},
+		"skip-empty": {
+			sc:    config.Scoring{IncludeEmpty: &no},
+			score: 25,
+			grade: "F",
+			ls: []report.LinterScore{
+				{Linter: "namespaces", Score: 100, Weight: 1},
+				{Linter: "pods", Resources: 4, Penalty: 2, Score: 50, Weight: 1, Contribution: 25, Counted: true},
+				{Linter: "secrets", Resources: 1, Penalty: 1, Score: 0, Weight: 1, Contribution: 0, Counted: true},
+			},
+		},
+		"weighted": {
+			sc: config.Scoring{
+				Linters:      map[string]float64{"pods": 3},
+				IncludeEmpty: &no,
+			},
+			score: 37,
+			grade: "F",
+			ls: []report.LinterScore{
+				{Linter: "namespaces", Score: 100, Weight: 1},
+				{Linter: "pods", Resources: 4, Penalty: 2, Score: 50, Weight: 3, Contribution: 37.5, Counted: true},
+				{Linter: "secrets", Resources: 1, Penalty: 1, Score: 0, Weight: 1, Contribution: 0, Counted: true},
+			},
+		},
+		"severities": {
+			sc: config.Scoring{
+				Severities:   map[string]float64{"warn": 0.5, "info": 0.25},
+				IncludeEmpty: &no,
+				Grades:       map[string]int{"D": 25},
+			},
+			score: 28,
+			grade: "D",
+			ls: []report.LinterScore{
+				{Linter: "namespaces", Score: 100, Weight: 1},
+				{Linter: "pods", Resources: 4, Penalty: 1.75, Score: 56, Weight: 1, Contribution: 28, Counted: true},
+				{Linter: "secrets", Resources: 1, Penalty: 1, Score: 0, Weight: 1, Contribution: 0, Counted: true},
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			b := report.NewBuilder()
+			b.SetScoring(u.sc)
+			po := issues.Outcome{
+				"default/p1": issues.Issues{
+					issues.New(types.NewGVR("v1/pods"), issues.Root, rules.ErrorLevel, "boom"),
+				},
+				"default/p2": issues.Issues{
+					issues.New(types.NewGVR("v1/pods"), issues.Root, rules.WarnLevel, "blee"),
+				},
+				"default/p3": issues.Issues{
+					issues.New(types.NewGVR("v1/pods"), issues.Root, rules.InfoLevel, "blah"),
+				},
+				"default/p4": issues.Issues{},
+			}
+			b.AddSection(types.NewGVR("v1/pods"), "pod", rules.OkLevel, po, report.NewTally().Rollup(po))
+			so := issues.Outcome{
+				"default/s1": issues.Issues{
+					issues.New(types.NewGVR("v1/secrets"), issues.Root, rules.ErrorLevel, "boom"),
+				},
+			}
+			b.AddSection(types.NewGVR("v1/secrets"), "secret", rules.OkLevel, so, report.NewTally().Rollup(so))
+			no := issues.Outcome{}
+			b.AddSection(types.NewGVR("v1/namespaces"), "namespace", rules.OkLevel, no, report.NewTally().Rollup(no))
+
+			bd := b.Breakdown()
+			assert.Equal(t, u.score, bd.Score)
+			assert.Equal(t, u.grade, bd.Grade)
+			assert.Equal(t, len(u.ls), len(bd.Linters))
+			for i, e := range u.ls {
+				assert.Equal(t, e.Linter, bd.Linters[i].Linter)
+				assert.Equal(t, e.Resources, bd.Linters[i].Resources)
+				assert.Equal(t, e.Penalty, bd.Linters[i].Penalty)
+				assert.Equal(t, e.Score, bd.Linters[i].Score)
+				assert.Equal(t, e.Weight, bd.Linters[i].Weight)
+				assert.InDelta(t, e.Contribution, bd.Linters[i].Contribution, 0.001)
+				assert.Equal(t, e.Counted, bd.Linters[i].Counted)
+			}
+			score, err := b.ToScore()
+			assert.NoError(t, err)
+			assert.Equal(t, u.score, score)
+		})
+	}
+}
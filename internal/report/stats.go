@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Provenance tracks how a scan was produced.
+type Provenance struct {
+	Version       string   `json:"version" yaml:"version"`
+	Commit        string   `json:"commit" yaml:"commit"`
+	ConfigHash    string   `json:"config_hash" yaml:"config_hash"`
+	Flags         []string `json:"flags,omitempty" yaml:"flags,omitempty"`
+	ServerVersion string   `json:"server_version,omitempty" yaml:"server_version,omitempty"`
+	Metrics       bool     `json:"metrics" yaml:"metrics"`
+}
+
+// Stats tracks scan performance.
+type Stats struct {
+	DurationMS int64        `json:"duration_ms" yaml:"duration_ms"`
+	Linters    []LinterStat `json:"linters" yaml:"linters"`
+	Loads      []LoadStat   `json:"loads" yaml:"loads"`
+}
+
+// LinterStat tracks a linter run.
+type LinterStat struct {
+	Linter     string `json:"linter" yaml:"linter"`
+	GVR        string `json:"gvr" yaml:"gvr"`
+	DurationMS int64  `json:"duration_ms" yaml:"duration_ms"`
+	Resources  int    `json:"resources" yaml:"resources"`
+}
+
+// LoadStat tracks a resource load.
+type LoadStat struct {
+	GVR        string `json:"gvr" yaml:"gvr"`
+	DurationMS int64  `json:"duration_ms" yaml:"duration_ms"`
+	Count      int    `json:"count" yaml:"count"`
+}
+
+// SetProvenance records the scan provenance.
+func (b *Builder) SetProvenance(p *Provenance) {
+	b.provenance = p
+}
+
+// AddLinterStat records a linter run.
+func (b *Builder) AddLinterStat(linter, gvr string, d time.Duration, resources int) {
+	if b.stats == nil {
+		b.stats = new(Stats)
+	}
+	b.stats.Linters = append(b.stats.Linters, LinterStat{
+		Linter:     linter,
+		GVR:        gvr,
+		DurationMS: d.Milliseconds(),
+		Resources:  resources,
+	})
+}
+
+// SetLoadStats records the scan resource loads and total duration.
+func (b *Builder) SetLoadStats(d time.Duration, ll []LoadStat) {
+	if b.stats == nil {
+		b.stats = new(Stats)
+	}
+	b.stats.DurationMS, b.stats.Loads = d.Milliseconds(), ll
+}
+
+// Stats returns the scan performance stats sorted by slowest first.
+func (b *Builder) Stats() *Stats {
+	if b.stats == nil {
+		return nil
+	}
+	s := Stats{
+		DurationMS: b.stats.DurationMS,
+		Linters:    slices.Clone(b.stats.Linters),
+		Loads:      slices.Clone(b.stats.Loads),
+	}
+	slices.SortStableFunc(s.Linters, func(a, b LinterStat) int {
+		return cmp.Or(cmp.Compare(b.DurationMS, a.DurationMS), cmp.Compare(a.Linter, b.Linter))
+	})
+	slices.SortStableFunc(s.Loads, func(a, b LoadStat) int {
+		return cmp.Or(cmp.Compare(b.DurationMS, a.DurationMS), cmp.Compare(a.GVR, b.GVR))
+	})
+	if s.Linters == nil {
+		s.Linters = make([]LinterStat, 0)
+	}
+	if s.Loads == nil {
+		s.Loads = make([]LoadStat, 0)
+	}
+
+	return &s
+}
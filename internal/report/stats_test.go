@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/derailed/popeye/pkg/config/json"
+	"github.com/derailed/popeye/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilderStats(t *testing.T) {
+	b := report.NewBuilder()
+	assert.Nil(t, b.Stats())
+
+	b.AddLinterStat("services", "v1/services", 20*time.Millisecond, 3)
+	b.AddLinterStat("pods", "v1/pods", 120*time.Millisecond, 10)
+	b.AddLinterStat("nodes", "v1/nodes", 20*time.Millisecond, 2)
+	b.SetLoadStats(150*time.Millisecond, []report.LoadStat{
+		{GVR: "v1/services", DurationMS: 5, Count: 3},
+		{GVR: "v1/pods", DurationMS: 80, Count: 10},
+	})
+
+	assert.Equal(t, &report.Stats{
+		DurationMS: 150,
+		Linters: []report.LinterStat{
+			{Linter: "pods", GVR: "v1/pods", DurationMS: 120, Resources: 10},
+			{Linter: "nodes", GVR: "v1/nodes", DurationMS: 20, Resources: 2},
+			{Linter: "services", GVR: "v1/services", DurationMS: 20, Resources: 3},
+		},
+		Loads: []report.LoadStat{
+			{GVR: "v1/pods", DurationMS: 80, Count: 10},
+			{GVR: "v1/services", DurationMS: 5, Count: 3},
+		},
+	}, b.Stats())
+}
+
+func TestBuilderScanProvenance(t *testing.T) {
+	o := issues.Outcome{
+		"default/p1": issues.Issues{
+			issues.New(types.NewGVR("v1/pods"), issues.Root, rules.ErrorLevel, "[POP-100] boom"),
+		},
+	}
+	b := report.NewBuilder()
+	b.AddSection(types.NewGVR("v1/pods"), "pod", rules.OkLevel, o, report.NewTally().Rollup(o))
+	b.SetProvenance(&report.Provenance{
+		Version:       "v1.0.0",
+		Commit:        "abc",
+		ConfigHash:    "1234",
+		Flags:         []string{"--out=json", "--token=***"},
+		ServerVersion: "v1.31.0",
+	})
+	b.AddLinterStat("pods", "v1/pods", 2*time.Millisecond, 1)
+	b.SetLoadStats(3*time.Millisecond, nil)
+	b.SetViolations([]report.Violation{{Policy: "errors", Condition: "error level issues exceeding 0", Value: 1}})
+
+	raw, err := b.Pivot(report.GroupByNamespace, nil).ToScanJSON(nil)
+	assert.NoError(t, err)
+	assert.Contains(t, raw, `"violations":[{"policy":"errors","condition":"error level issues exceeding 0","value":1}]`)
+	assert.Contains(t, raw, `"provenance":{"version":"v1.0.0","commit":"abc","config_hash":"1234","flags":["--out=json","--token=***"],"server_version":"v1.31.0","metrics":false}`)
+	assert.Contains(t, raw, `"stats":{"duration_ms":3,"linters":[{"linter":"pods","gvr":"v1/pods","duration_ms":2,"resources":1}],"loads":[]}`)
+	assert.NoError(t, json.NewValidator().Validate(json.ReportSchema, []byte(raw)))
+}
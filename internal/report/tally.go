@@ -13,15 +13,17 @@ import (
 	"github.com/derailed/popeye/internal/issues"
 	"github.com/derailed/popeye/internal/lint"
 	"github.com/derailed/popeye/internal/rules"
+	"github.com/derailed/popeye/pkg/config"
 )
 
 const targetScore = 80
 
 // Tally tracks lint section scores.
 type Tally struct {
-	counts []int
-	score  int
-	valid  bool
+	counts  []int
+	score   int
+	penalty float64
+	valid   bool
 }
 
 // NewTally returns a new tally.
@@ -36,6 +38,16 @@ func (t *Tally) Score() int {
 	return t.score
 }
 
+// OkCount returns the number of resources without issues.
+func (t *Tally) OkCount() int {
+	return t.counts[0]
+}
+
+// InfoCount returns the number of infos found.
+func (t *Tally) InfoCount() int {
+	return t.counts[1]
+}
+
 // ErrCount returns the number of errors found.
 func (t *Tally) ErrCount() int {
 	return t.counts[3]
@@ -81,6 +93,31 @@ func (t *Tally) computeScore() int {
 	return t.score
 }
 
+// rescore computes the tally score using the given scoring model. Each
+// resource is penalized based on its highest issue severity.
+func (t *Tally) rescore(sc config.Scoring) *Tally {
+	total := t.total()
+	if total == 0 {
+		return t
+	}
+	t.penalty = 0
+	for i, v := range t.counts {
+		t.penalty += float64(v) * sc.Penalty(rules.Level(i))
+	}
+	t.score = int(toPerc(float64(total)-t.penalty, float64(total)))
+
+	return t
+}
+
+func (t *Tally) total() int {
+	var total int
+	for _, v := range t.counts {
+		total += v
+	}
+
+	return total
+}
+
 // Write out a tally.
 func (t *Tally) write(w io.Writer, s *ScanReport) {
 	for i := len(t.counts) - 1; i >= 0; i-- {
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report
+
+import (
+	"sort"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/types"
+)
+
+// SplitByTeam splits reports by owning team.
+const SplitByTeam = "team"
+
+// TeamFunc resolves the team owning a given linter resource.
+type TeamFunc func(gvr, fqn string) string
+
+// SetTeams sets the resolver used to assign resources to their owning team.
+func (b *Builder) SetTeams(f TeamFunc) {
+	b.teams = f
+}
+
+// HasTeams returns true if resources are assigned to teams.
+func (b *Builder) HasTeams() bool {
+	return b.teams != nil
+}
+
+// Team returns the team owning a given linter resource if any.
+func (b *Builder) Team(gvr, fqn string) string {
+	if b.teams == nil {
+		return ""
+	}
+
+	return b.teams(gvr, fqn)
+}
+
+// Split returns one report per owning team sorted by team name. Each team
+// report only lists the resources owned by the team and is scored on those.
+// Linters with no resources owned by a team are omitted from its report.
+func (b *Builder) Split() []*Builder {
+	if b.teams == nil {
+		return []*Builder{b}
+	}
+
+	tt := make(map[string]*Builder)
+	for _, s := range b.Report.Sections {
+		oo := make(map[string]issues.Outcome)
+		for fqn, ii := range s.Outcome {
+			team := b.teams(s.GVR, fqn)
+			if _, ok := oo[team]; !ok {
+				oo[team] = make(issues.Outcome)
+			}
+			oo[team][fqn] = ii
+		}
+		for team, o := range oo {
+			tb, ok := tt[team]
+			if !ok {
+				tb = b.forTeam(team)
+				tt[team] = tb
+			}
+			tb.AddSection(types.NewGVR(s.GVR), s.singular, s.level, o, NewTally().Rollup(o))
+		}
+	}
+
+	bb := make([]*Builder, 0, len(tt))
+	for _, tb := range tt {
+		sort.Sort(tb.Report.Sections)
+		bb = append(bb, tb)
+	}
+	sort.Slice(bb, func(i, j int) bool {
+		return bb[i].Report.Team < bb[j].Report.Team
+	})
+
+	return bb
+}
+
+func (b *Builder) forTeam(team string) *Builder {
+	return &Builder{
+		ClusterName: b.ClusterName,
+		ContextName: b.ContextName,
+		scoring:     b.scoring,
+		provenance:  b.provenance,
+		teams:       b.teams,
+		Report: Report{
+			Timestamp: b.Report.Timestamp,
+			Team:      team,
+		},
+	}
+}
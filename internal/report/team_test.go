@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package report_test
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/derailed/popeye/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilderSplit(t *testing.T) {
+	type team struct {
+		score     int
+		linters   []string
+		resources []string
+	}
+	uu := map[string]struct {
+		teams report.TeamFunc
+		e     map[string]team
+	}{
+		"none": {
+			e: map[string]team{
+				"": {score: 33, linters: []string{"nodes", "pods"}, resources: []string{"n1", "default/p1", "default/p2", "fred/p3"}},
+			},
+		},
+		"teams": {
+			teams: func(gvr, fqn string) string {
+				switch fqn {
+				case "default/p1", "default/p2":
+					return "payments"
+				case "fred/p3":
+					return "search"
+				default:
+					return "unowned"
+				}
+			},
+			e: map[string]team{
+				"payments": {score: 50, linters: []string{"pods"}, resources: []string{"default/p1", "default/p2"}},
+				"search":   {score: 100, linters: []string{"pods"}, resources: []string{"fred/p3"}},
+				"unowned":  {score: 0, linters: []string{"nodes"}, resources: []string{"n1"}},
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			b := report.NewBuilder()
+			po := issues.Outcome{
+				"default/p1": issues.Issues{
+					issues.New(types.NewGVR("v1/pods"), issues.Root, rules.ErrorLevel, "[POP-100] Blah"),
+				},
+				"default/p2": issues.Issues{},
+				"fred/p3":    issues.Issues{},
+			}
+			b.AddSection(types.NewGVR("v1/pods"), "pod", rules.OkLevel, po, report.NewTally().Rollup(po))
+			no := issues.Outcome{
+				"n1": issues.Issues{
+					issues.New(types.NewGVR("v1/nodes"), issues.Root, rules.ErrorLevel, "boom"),
+				},
+			}
+			b.AddSection(types.NewGVR("v1/nodes"), "node", rules.OkLevel, no, report.NewTally().Rollup(no))
+			if u.teams != nil {
+				b.SetTeams(u.teams)
+			}
+
+			bb := b.Split()
+			assert.Equal(t, len(u.e), len(bb))
+			for _, tb := range bb {
+				e, ok := u.e[tb.Report.Team]
+				assert.True(t, ok, tb.Report.Team)
+				score, err := tb.ToScore()
+				assert.NoError(t, err)
+				assert.Equal(t, e.score, score)
+				var ll, rr []string
+				for _, s := range tb.Report.Sections {
+					ll = append(ll, s.Title)
+					for fqn := range s.Outcome {
+						rr = append(rr, fqn)
+					}
+				}
+				assert.ElementsMatch(t, e.linters, ll)
+				assert.ElementsMatch(t, e.resources, rr)
+			}
+		})
+	}
+}
+
+func TestToScanTeam(t *testing.T) {
+	b := report.NewBuilder()
+	po := issues.Outcome{
+		"default/p1": issues.Issues{
+			issues.New(types.NewGVR("v1/pods"), issues.Root, rules.ErrorLevel, "[POP-100] Blah"),
+		},
+	}
+	b.AddSection(types.NewGVR("v1/pods"), "pod", rules.OkLevel, po, report.NewTally().Rollup(po))
+	b.SetTeams(func(gvr, fqn string) string { return "payments" })
+
+	all := b.ToScan(nil)
+	assert.Empty(t, all.Team)
+	assert.Equal(t, "payments", all.Sections[0].Issues[0].Team)
+
+	bb := b.Split()
+	assert.Len(t, bb, 1)
+	s := bb[0].ToScan(nil)
+	assert.Equal(t, "payments", s.Team)
+	assert.Equal(t, 0, s.Score)
+	assert.Equal(t, "payments", s.Sections[0].Issues[0].Team)
+}
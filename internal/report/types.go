@@ -24,6 +24,24 @@ const (
 	// JunitFormat renders report as JUnit.
 	JunitFormat = "junit"
 
+	// CSVFormat renders report as comma separated values.
+	CSVFormat = "csv"
+
+	// TSVFormat renders report as tab separated values.
+	TSVFormat = "tsv"
+
+	// CodeQualityFormat renders report as GitLab Code Quality.
+	CodeQualityFormat = "codequality"
+
+	// MarkdownFormat renders report as Markdown.
+	MarkdownFormat = "markdown"
+
+	// SARIFFormat renders report as SARIF.
+	SARIFFormat = "sarif"
+
+	// PolicyReportFormat renders report as wgpolicyk8s.io policy reports.
+	PolicyReportFormat = "policyreport"
+
 	// ScoreFormat renders report as the value of the Score.
 	ScoreFormat = "score"
 
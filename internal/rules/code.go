@@ -11,8 +11,12 @@ import (
 
 // Code represents an issue code.
 type Code struct {
-	Message  string `yaml:"message"`
-	Severity Level  `yaml:"severity"`
+	Message     string   `yaml:"message" json:"message"`
+	Severity    Level    `yaml:"severity" json:"severity"`
+	Linters     []string `yaml:"linters,omitempty" json:"linters,omitempty"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Remediation string   `yaml:"remediation,omitempty" json:"remediation,omitempty"`
+	Example     string   `yaml:"example,omitempty" json:"example,omitempty"`
 }
 
 // Format hydrates a message with arguments.
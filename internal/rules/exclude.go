@@ -5,6 +5,7 @@ package rules
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/rs/zerolog/log"
@@ -34,11 +35,11 @@ func (ee Excludes) Match(spec Spec, global bool) bool {
 }
 
 type Exclude struct {
-	FQNs        expressions `yam:"FQNs"`
-	Labels      keyVals     `yaml:"labels"`
-	Annotations keyVals     `yaml:"annotations"`
-	Codes       expressions `yaml:"codes"`
-	Containers  expressions `yaml:"containers"`
+	FQNs        expressions `yaml:"fqns,omitempty"`
+	Labels      keyVals     `yaml:"labels,omitempty"`
+	Annotations keyVals     `yaml:"annotations,omitempty"`
+	Codes       expressions `yaml:"codes,omitempty"`
+	Containers  expressions `yaml:"containers,omitempty"`
 }
 
 // NewExclude returns a new instance.
@@ -49,6 +50,15 @@ func NewExclude() Exclude {
 	}
 }
 
+// Merge appends the given exclude rules to copies of this one.
+func (e *Exclude) Merge(o Exclude) {
+	e.FQNs = slices.Concat(e.FQNs, o.FQNs)
+	e.Labels = e.Labels.merge(o.Labels)
+	e.Annotations = e.Annotations.merge(o.Annotations)
+	e.Codes = slices.Concat(e.Codes, o.Codes)
+	e.Containers = slices.Concat(e.Containers, o.Containers)
+}
+
 func (e Exclude) Dump(indent string) {
 	fmt.Printf("%sFQNS\n", indent)
 	e.FQNs.dump(strings.Repeat(indent, 2))
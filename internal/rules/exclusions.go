@@ -33,6 +33,15 @@ func (e Exclusions) Match(spec Spec) bool {
 	return e.Linters.Match(spec, false)
 }
 
+// Merge appends the given exclusions to this set.
+func (e *Exclusions) Merge(o Exclusions) {
+	e.Global.Merge(o.Global)
+	if e.Linters == nil {
+		e.Linters = make(Linters, len(o.Linters))
+	}
+	e.Linters = e.Linters.Merge(o.Linters)
+}
+
 func (e Exclusions) Dump() {
 	fmt.Println("Globals")
 	e.Global.Dump("  ")
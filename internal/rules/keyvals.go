@@ -5,6 +5,7 @@ package rules
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 )
 
@@ -31,6 +32,12 @@ func (kv keyVals) dump(indent string) {
 	}
 }
 
+func (kv keyVals) merge(o keyVals) keyVals {
+	return MergeMapFunc(kv, o, func(a, b expressions) expressions {
+		return slices.Concat(a, b)
+	})
+}
+
 func (kv keyVals) isEmpty() bool {
 	return len(kv) == 0
 }
@@ -5,14 +5,15 @@ package rules
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/rs/zerolog/log"
 )
 
 type LinterExcludes struct {
-	Codes     expressions `yaml:"codes"`
-	Instances Excludes    `yaml:"instances"`
+	Codes     expressions `yaml:"codes,omitempty"`
+	Instances Excludes    `yaml:"instances,omitempty"`
 }
 
 func (l LinterExcludes) Dump(indent string) {
@@ -32,8 +33,22 @@ func (l LinterExcludes) Match(spec Spec, global bool) bool {
 	return l.Codes.match(spec.Code.String())
 }
 
+// Merge appends the given linter exclusions to copies of this one.
+func (l *LinterExcludes) Merge(o LinterExcludes) {
+	l.Codes = slices.Concat(l.Codes, o.Codes)
+	l.Instances = slices.Concat(l.Instances, o.Instances)
+}
+
 type Linters map[string]LinterExcludes
 
+// Merge returns the given linters exclusions appended to a copy of this set.
+func (l Linters) Merge(o Linters) Linters {
+	return MergeMapFunc(l, o, func(a, b LinterExcludes) LinterExcludes {
+		a.Merge(b)
+		return a
+	})
+}
+
 func (l Linters) Dump(indent string) {
 	for k, v := range l {
 		fmt.Printf("%s%s\n", indent, k)
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package rules
+
+import (
+	"maps"
+	"slices"
+)
+
+// MergeBy returns the given items layered on a copy of s. Items sharing the
+// same key are replaced. s is left untouched as it may be shared with another
+// config layer.
+func MergeBy[S ~[]E, E any, K comparable](s, o S, key func(E) K) S {
+	s = slices.Clone(s)
+	for _, e := range o {
+		idx := slices.IndexFunc(s, func(c E) bool { return key(c) == key(e) })
+		if idx >= 0 {
+			s[idx] = e
+			continue
+		}
+		s = append(s, e)
+	}
+
+	return s
+}
+
+// MergeMap returns the given entries layered on a copy of m. Entries sharing
+// the same key are replaced.
+func MergeMap[M ~map[K]V, K comparable, V any](m, o M) M {
+	return MergeMapFunc(m, o, func(_, v V) V { return v })
+}
+
+// MergeMapFunc returns the given entries layered on a copy of m. Entries
+// sharing the same key are combined using fn. m is left untouched as it may be
+// shared with another config layer.
+func MergeMapFunc[M ~map[K]V, K comparable, V any](m, o M, fn func(V, V) V) M {
+	if len(o) == 0 {
+		return m
+	}
+	mm := make(M, len(m)+len(o))
+	maps.Copy(mm, m)
+	for k, v := range o {
+		if prev, ok := mm[k]; ok {
+			v = fn(prev, v)
+		}
+		mm[k] = v
+	}
+
+	return mm
+}
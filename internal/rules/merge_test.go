@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeBy(t *testing.T) {
+	base := Overrides{{ID: 100, Severity: InfoLevel}, {ID: 101, Severity: InfoLevel}}
+	oo := base.Merge(Overrides{{ID: 101, Severity: ErrorLevel}, {ID: 102, Severity: WarnLevel}})
+
+	assert.Equal(t, Overrides{{ID: 100, Severity: InfoLevel}, {ID: 101, Severity: ErrorLevel}, {ID: 102, Severity: WarnLevel}}, oo)
+	assert.Equal(t, Overrides{{ID: 100, Severity: InfoLevel}, {ID: 101, Severity: InfoLevel}}, base)
+}
+
+func TestMergeMap(t *testing.T) {
+	uu := map[string]struct {
+		m, o, e map[string]int
+	}{
+		"empty": {},
+		"nil-base": {
+			o: map[string]int{"a": 1},
+			e: map[string]int{"a": 1},
+		},
+		"no-layer": {
+			m: map[string]int{"a": 1},
+			e: map[string]int{"a": 1},
+		},
+		"replace": {
+			m: map[string]int{"a": 1, "b": 2},
+			o: map[string]int{"b": 3, "c": 4},
+			e: map[string]int{"a": 1, "b": 3, "c": 4},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			var before map[string]int
+			if u.m != nil {
+				before = make(map[string]int, len(u.m))
+				for k, v := range u.m {
+					before[k] = v
+				}
+			}
+			assert.Equal(t, u.e, MergeMap(u.m, u.o))
+			assert.Equal(t, before, u.m)
+		})
+	}
+}
+
+func TestKeyValsMerge(t *testing.T) {
+	base := keyVals{"app": expressions{"fred"}}
+	kv := base.merge(keyVals{"app": expressions{"blee"}, "tier": expressions{"web"}})
+
+	assert.Equal(t, keyVals{"app": expressions{"fred", "blee"}, "tier": expressions{"web"}}, kv)
+	assert.Equal(t, keyVals{"app": expressions{"fred"}}, base)
+}
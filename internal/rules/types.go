@@ -3,7 +3,9 @@
 
 package rules
 
-import "strconv"
+import (
+	"strconv"
+)
 
 const ZeroCode ID = 0
 
@@ -15,16 +17,22 @@ func (i ID) String() string {
 }
 
 // IDS tracks a collection of ids.
-type IDS map[Code]struct{}
+type IDS map[ID]struct{}
 
 type CodeOverride struct {
 	ID       ID     `yaml:"code"`
-	Message  string `yaml:"message"`
+	Message  string `yaml:"message,omitempty"`
 	Severity Level  `yaml:"severity"`
 }
 
-// Glossary represents a collection of codes.
+// Overrides represents a collection of code overrides.
 type Overrides []CodeOverride
 
+// Merge returns the given overrides layered on a copy of this set. Later
+// overrides replace earlier ones for the same code.
+func (oo Overrides) Merge(o Overrides) Overrides {
+	return MergeBy(oo, o, func(c CodeOverride) ID { return c.ID })
+}
+
 // Glossary represents a collection of codes.
 type Glossary map[ID]*Code
@@ -25,6 +25,7 @@ type LoaderFn func(context.Context, *db.Loader, types.GVR) error
 type Collector interface {
 	MaxSeverity(res string) rules.Level
 	Outcome() issues.Outcome
+	Skipped() []string
 }
 
 // Linter represents a resource linter.
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package internal
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// TracerName tracks Popeye's instrumentation scope.
+const TracerName = "github.com/derailed/popeye"
+
+// WithTracer returns a context carrying the scan tracer.
+func WithTracer(ctx context.Context, t trace.Tracer) context.Context {
+	return context.WithValue(ctx, KeyTracer, t)
+}
+
+// Tracer returns the scan tracer carried by the context. Spans are dropped
+// unless an OTLP exporter is configured.
+func Tracer(ctx context.Context) trace.Tracer {
+	if t, ok := ctx.Value(KeyTracer).(trace.Tracer); ok {
+		return t
+	}
+
+	return noop.NewTracerProvider().Tracer(TracerName)
+}
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package internal_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracer(t *testing.T) {
+	_, span := internal.Tracer(context.Background()).Start(context.Background(), "noop")
+	assert.False(t, span.SpanContext().IsValid())
+	span.End()
+
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	ctx := internal.WithTracer(context.Background(), tp.Tracer(internal.TracerName))
+	_, span = internal.Tracer(ctx).Start(ctx, "scan")
+	span.End()
+
+	assert.Len(t, rec.Ended(), 1)
+	assert.Equal(t, "scan", rec.Ended()[0].Name())
+}
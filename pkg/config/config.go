@@ -4,12 +4,10 @@
 package config
 
 import (
-	"fmt"
-	"os"
+	"io"
 
 	"github.com/derailed/popeye/internal/client"
 	"github.com/derailed/popeye/internal/rules"
-	"github.com/derailed/popeye/pkg/config/json"
 	"github.com/derailed/popeye/types"
 	"gopkg.in/yaml.v2"
 )
@@ -18,9 +16,10 @@ const defaultLintLevel = "ok"
 
 // Config tracks Popeye configuration options.
 type Config struct {
-	Popeye    `yaml:"popeye"`
-	Flags     *Flags
-	LintLevel int
+	Popeye     `yaml:"popeye"`
+	Flags      *Flags
+	LintLevel  int
+	lintLevels map[string]rules.Level
 }
 
 // NewConfig create a new Popeye configuration.
@@ -29,16 +28,15 @@ func NewConfig(flags *Flags) (*Config, error) {
 		Popeye: NewPopeye(),
 	}
 
-	if isSet(flags.Spinach) {
-		bb, err := os.ReadFile(*flags.Spinach)
-		if err != nil {
+	if IsStrSet(flags.Profile) {
+		if err := loadProfile(*flags.Profile, &cfg.Popeye); err != nil {
 			return nil, err
 		}
-		if err := json.NewValidator().Validate(json.SpinachSchema, bb); err != nil {
-			return nil, fmt.Errorf("validation failed for %q: %w", *flags.Spinach, err)
-		}
-		if err := yaml.Unmarshal(bb, &cfg); err != nil {
-			return nil, fmt.Errorf("Invalid spinach config file -- %w", err)
+	}
+	l := newSpinachLoader()
+	for _, f := range flags.spinachFiles() {
+		if err := l.load(f, &cfg.Popeye); err != nil {
+			return nil, err
 		}
 	}
 	cfg.Flags = flags
@@ -50,11 +48,44 @@ func NewConfig(flags *Flags) (*Config, error) {
 		all := client.NamespaceAll
 		flags.Namespace = &all
 	}
-	cfg.LintLevel = int(rules.ToIssueLevel(flags.LintLevel))
+	var level string
+	if flags.LintLevel != nil {
+		var (
+			ll  LintLevels
+			err error
+		)
+		level, ll, err = parseLintLevel(*flags.LintLevel)
+		if err != nil {
+			return nil, err
+		}
+		cfg.LintLevels = cfg.LintLevels.Merge(ll)
+	}
+	cfg.LintLevel = int(rules.ToIssueLevel(&level))
+	cfg.lintLevels = cfg.LintLevels.toLevels()
 
 	return &cfg, nil
 }
 
+// Dump writes out the effective configuration as a spinach file.
+func (c *Config) Dump(w io.Writer) error {
+	raw, err := yaml.Marshal(Spinach{Popeye: c.Popeye})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(raw)
+
+	return err
+}
+
+// LintLevelFor returns the minimum lint level for a given linter.
+func (c *Config) LintLevelFor(linter string) rules.Level {
+	if l, ok := c.lintLevels[linter]; ok {
+		return l
+	}
+
+	return rules.Level(c.LintLevel)
+}
+
 func (c *Config) Match(s rules.Spec) bool {
 	return c.Popeye.Match(s)
 }
@@ -143,10 +174,3 @@ func (c *Config) NodeMEMLimit() float64 {
 func (c *Config) AllowedRegistries() []string {
 	return c.Registries
 }
-
-// ----------------------------------------------------------------------------
-// Helpers...
-
-func isSet(s *string) bool {
-	return s != nil && *s != ""
-}
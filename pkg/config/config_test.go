@@ -321,3 +321,138 @@ func TestNewConfigFileNoExists(t *testing.T) {
 	_, err := config.NewConfig(f)
 	assert.NotNil(t, err)
 }
+
+func TestNewConfigLayers(t *testing.T) {
+	f := config.NewFlags()
+	sp := "testdata/layers/delta.yml"
+	f.Spinach = &sp
+	f.SpinachLayers = &[]string{"testdata/layers/overlay.yml"}
+
+	cfg, err := config.NewConfig(f)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, cfg.RestartsLimit())
+	assert.Equal(t, 70.0, cfg.PodCPULimit())
+	assert.Equal(t, 60.0, cfg.PodMEMLimit())
+	assert.Equal(t, []string{"docker.io", "quay.io"}, cfg.Registries)
+	assert.Equal(t, rules.Overrides{{ID: 206, Severity: 3}}, cfg.Overrides)
+
+	for _, fqn := range []string{"kube-system/p1", "ns2/p1"} {
+		assert.True(t, cfg.Match(rules.Spec{GVR: types.NewGVR("v1/pods"), FQN: fqn}), fqn)
+	}
+	assert.True(t, cfg.Match(rules.Spec{GVR: types.NewGVR("v1/pods"), FQN: "ns1/p1", Code: 100}))
+	assert.True(t, cfg.Match(rules.Spec{GVR: types.NewGVR("v1/pods"), FQN: "ns3/p1", Code: 101}))
+	assert.False(t, cfg.Match(rules.Spec{GVR: types.NewGVR("v1/pods"), FQN: "ns3/p1", Code: 100}))
+}
+
+func TestNewConfigIncludeCycle(t *testing.T) {
+	f := config.NewFlags()
+	sp := "testdata/layers/cycle-1.yml"
+	f.Spinach = &sp
+
+	_, err := config.NewConfig(f)
+	assert.ErrorContains(t, err, "spinach include cycle detected")
+}
+
+func TestNewConfigProfiles(t *testing.T) {
+	pp := config.Profiles()
+	assert.Equal(t, []string{"aks", "eks", "gke", "kind", "openshift"}, pp)
+
+	for _, p := range pp {
+		t.Run(p, func(t *testing.T) {
+			f := config.NewFlags()
+			f.Profile = &p
+			cfg, err := config.NewConfig(f)
+			assert.NoError(t, err)
+			assert.True(t, cfg.Match(rules.Spec{GVR: types.NewGVR("v1/pods"), FQN: "kube-system/p1"}))
+			assert.False(t, cfg.Match(rules.Spec{GVR: types.NewGVR("v1/pods"), FQN: "fred/p1"}))
+		})
+	}
+}
+
+func TestNewConfigProfileLayering(t *testing.T) {
+	var (
+		p = "kind"
+		f = config.NewFlags()
+	)
+	f.Profile = &p
+	sp := "testdata/layers/base.yml"
+	f.Spinach = &sp
+
+	cfg, err := config.NewConfig(f)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, cfg.RestartsLimit())
+	assert.True(t, cfg.Match(rules.Spec{GVR: types.NewGVR("v1/pods"), FQN: "local-path-storage/p1"}))
+	assert.True(t, cfg.Match(rules.Spec{GVR: types.NewGVR("v1/pods"), FQN: "ns1/p1", Code: 100}))
+}
+
+func TestNewConfigProfileToast(t *testing.T) {
+	var (
+		p = "bozo"
+		f = config.NewFlags()
+	)
+	f.Profile = &p
+
+	_, err := config.NewConfig(f)
+	assert.ErrorContains(t, err, `invalid profile "bozo"`)
+}
+
+func TestNewConfigLintLevels(t *testing.T) {
+	uu := map[string]struct {
+		spec string
+		err  string
+		ee   map[string]rules.Level
+	}{
+		"global": {
+			spec: "warn",
+			ee: map[string]rules.Level{
+				"pods":       rules.WarnLevel,
+				"nodes":      rules.ErrorLevel,
+				"configmaps": rules.WarnLevel,
+			},
+		},
+		"linters": {
+			spec: "info,configmaps=error,pods=ok",
+			ee: map[string]rules.Level{
+				"pods":       rules.OkLevel,
+				"nodes":      rules.ErrorLevel,
+				"configmaps": rules.ErrorLevel,
+				"secrets":    rules.InfoLevel,
+			},
+		},
+		"linters-only": {
+			spec: "nodes=info",
+			ee: map[string]rules.Level{
+				"pods":  rules.OkLevel,
+				"nodes": rules.InfoLevel,
+			},
+		},
+		"toast-level": {
+			spec: "blee",
+			err:  `invalid lint level "blee". [ok,info,warn,error]`,
+		},
+		"toast-linter-level": {
+			spec: "nodes=blee",
+			err:  `invalid linter lint level "nodes=blee". [ok,info,warn,error]`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			f := config.NewFlags()
+			sp := "testdata/sp-levels.yml"
+			f.Spinach = &sp
+			f.LintLevel = &u.spec
+			cfg, err := config.NewConfig(f)
+			if u.err != "" {
+				assert.Equal(t, u.err, err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			for linter, e := range u.ee {
+				assert.Equal(t, e, cfg.LintLevelFor(linter), linter)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package config
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/popeye/internal/rules"
+)
+
+var exitLevels = []string{"ok", "info", "warn", "error"}
+
+// ExitPolicies tracks a collection of exit policies.
+type ExitPolicies []ExitPolicy
+
+// ExitPolicy tracks a condition failing the scan. A policy is violated when
+// the number of matching issues exceeds its max or grows past the baseline.
+type ExitPolicy struct {
+	// Name identifies the policy.
+	Name string `yaml:"name"`
+
+	// Level the minimum issue level ie info, warn or error. Defaults to error
+	// unless codes are given in which case issues of any level match.
+	Level string `yaml:"level,omitempty"`
+
+	// Namespaces the namespace patterns to match ie prod-*.
+	Namespaces []string `yaml:"namespaces,omitempty"`
+
+	// Linters the linters to match ie pods.
+	Linters []string `yaml:"linters,omitempty"`
+
+	// Codes the issue codes to match ie 1105.
+	Codes []rules.ID `yaml:"codes,omitempty"`
+
+	// Max the number of matching issues allowed. Defaults to 0.
+	Max int `yaml:"max,omitempty"`
+
+	// Baseline compares matching issues against the baseline report.
+	Baseline bool `yaml:"baseline,omitempty"`
+}
+
+// ParseExitPolicy parses a policy given as comma separated key=value pairs
+// ie level=error,namespace=prod-*. Keys: name, level, namespace, linter,
+// code, max and baseline. Repeated keys are cumulative.
+func ParseExitPolicy(spec string) (ExitPolicy, error) {
+	p := ExitPolicy{Name: spec}
+	for _, kv := range strings.Split(spec, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(kv), "=")
+		if !ok || v == "" {
+			return ExitPolicy{}, fmt.Errorf("invalid exit policy %q. Expecting key=value pairs", spec)
+		}
+		switch k {
+		case "name":
+			p.Name = v
+		case "level":
+			p.Level = v
+		case "namespace", "namespaces":
+			p.Namespaces = append(p.Namespaces, v)
+		case "linter", "linters":
+			p.Linters = append(p.Linters, v)
+		case "code", "codes":
+			id, err := strconv.Atoi(strings.TrimPrefix(v, "POP-"))
+			if err != nil {
+				return ExitPolicy{}, fmt.Errorf("invalid exit policy %q code %q", spec, v)
+			}
+			p.Codes = append(p.Codes, rules.ID(id))
+		case "max":
+			m, err := strconv.Atoi(v)
+			if err != nil {
+				return ExitPolicy{}, fmt.Errorf("invalid exit policy %q max %q", spec, v)
+			}
+			p.Max = m
+		case "baseline":
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return ExitPolicy{}, fmt.Errorf("invalid exit policy %q baseline %q", spec, v)
+			}
+			p.Baseline = b
+		default:
+			return ExitPolicy{}, fmt.Errorf("invalid exit policy %q key %q", spec, k)
+		}
+	}
+
+	return p, p.Validate()
+}
+
+// Merge returns the given policies layered on a copy of these ones. Policies
+// with the same name are replaced.
+func (pp ExitPolicies) Merge(oo ExitPolicies) ExitPolicies {
+	return rules.MergeBy(pp, oo, func(p ExitPolicy) string { return p.Name })
+}
+
+// HasBaseline returns true if any policy requires a baseline report.
+func (pp ExitPolicies) HasBaseline() bool {
+	return slices.ContainsFunc(pp, func(p ExitPolicy) bool { return p.Baseline })
+}
+
+// Validate checks the policy configuration.
+func (p ExitPolicy) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("exit policy name is required")
+	}
+	if p.Level != "" && !slices.Contains(exitLevels, p.Level) {
+		return fmt.Errorf("exit policy %q invalid level %q. [%s]", p.Name, p.Level, strings.Join(exitLevels, ","))
+	}
+	for _, ns := range p.Namespaces {
+		if _, err := path.Match(ns, ""); err != nil {
+			return fmt.Errorf("exit policy %q invalid namespace pattern %q", p.Name, ns)
+		}
+	}
+	if p.Max < 0 {
+		return fmt.Errorf("exit policy %q max must not be negative", p.Name)
+	}
+
+	return nil
+}
+
+// MinLevel returns the minimum level of matching issues.
+func (p ExitPolicy) MinLevel() rules.Level {
+	if p.Level == "" {
+		if len(p.Codes) > 0 {
+			return rules.OkLevel
+		}
+		return rules.ErrorLevel
+	}
+
+	return rules.ToIssueLevel(&p.Level)
+}
+
+// Matches returns true if an issue matches the policy.
+func (p ExitPolicy) Matches(level rules.Level, code rules.ID, linter, ns string) bool {
+	if level < p.MinLevel() {
+		return false
+	}
+	if len(p.Codes) > 0 && !slices.Contains(p.Codes, code) {
+		return false
+	}
+	if len(p.Linters) > 0 && !slices.Contains(p.Linters, linter) {
+		return false
+	}
+	if len(p.Namespaces) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(p.Namespaces, func(pat string) bool {
+		ok, _ := path.Match(pat, ns)
+		return ok
+	})
+}
+
+// String returns a human readable policy condition.
+func (p ExitPolicy) String() string {
+	ss := []string{"issues"}
+	if l := p.MinLevel(); l > rules.OkLevel {
+		ss[0] = fmt.Sprintf("%s level issues", l.ToHumanLevel())
+	}
+	if len(p.Codes) > 0 {
+		cc := make([]string, 0, len(p.Codes))
+		for _, c := range p.Codes {
+			cc = append(cc, fmt.Sprintf("POP-%d", c))
+		}
+		ss = append(ss, "with codes "+strings.Join(cc, ","))
+	}
+	if len(p.Linters) > 0 {
+		ss = append(ss, "in linters "+strings.Join(p.Linters, ","))
+	}
+	if len(p.Namespaces) > 0 {
+		ss = append(ss, "in namespaces "+strings.Join(p.Namespaces, ","))
+	}
+	if p.Baseline {
+		ss = append(ss, fmt.Sprintf("exceeding the baseline by more than %d", p.Max))
+	} else {
+		ss = append(ss, fmt.Sprintf("exceeding %d", p.Max))
+	}
+
+	return strings.Join(ss, " ")
+}
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package config
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExitPolicy(t *testing.T) {
+	uu := map[string]struct {
+		spec string
+		e    ExitPolicy
+		err  string
+	}{
+		"namespace": {
+			spec: "level=error,namespace=prod-*",
+			e:    ExitPolicy{Name: "level=error,namespace=prod-*", Level: "error", Namespaces: []string{"prod-*"}},
+		},
+		"codes": {
+			spec: "name=no-dangling,code=1105,code=POP-1106",
+			e:    ExitPolicy{Name: "no-dangling", Codes: []rules.ID{1105, 1106}},
+		},
+		"baseline": {
+			spec: "linter=pods,baseline=true,max=2",
+			e:    ExitPolicy{Name: "linter=pods,baseline=true,max=2", Linters: []string{"pods"}, Baseline: true, Max: 2},
+		},
+		"no-value": {
+			spec: "level",
+			err:  `invalid exit policy "level". Expecting key=value pairs`,
+		},
+		"key": {
+			spec: "fred=blee",
+			err:  `invalid exit policy "fred=blee" key "fred"`,
+		},
+		"code": {
+			spec: "code=fred",
+			err:  `invalid exit policy "code=fred" code "fred"`,
+		},
+		"level": {
+			spec: "level=fatal",
+			err:  `exit policy "level=fatal" invalid level "fatal". [ok,info,warn,error]`,
+		},
+		"max": {
+			spec: "max=-1",
+			err:  `exit policy "max=-1" max must not be negative`,
+		},
+		"pattern": {
+			spec: "namespace=[",
+			err:  `exit policy "namespace=[" invalid namespace pattern "["`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			p, err := ParseExitPolicy(u.spec)
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, u.e, p)
+		})
+	}
+}
+
+func TestExitPolicyMatches(t *testing.T) {
+	type issue struct {
+		level      rules.Level
+		code       rules.ID
+		linter, ns string
+	}
+	uu := map[string]struct {
+		p ExitPolicy
+		i issue
+		e bool
+	}{
+		"default-error": {
+			p: ExitPolicy{Name: "p"},
+			i: issue{level: rules.ErrorLevel, linter: "pods", ns: "fred"},
+			e: true,
+		},
+		"default-warn": {
+			p: ExitPolicy{Name: "p"},
+			i: issue{level: rules.WarnLevel, linter: "pods", ns: "fred"},
+		},
+		"code-any-level": {
+			p: ExitPolicy{Name: "p", Codes: []rules.ID{1105}},
+			i: issue{level: rules.InfoLevel, code: 1105, linter: "secrets", ns: "fred"},
+			e: true,
+		},
+		"code-miss": {
+			p: ExitPolicy{Name: "p", Codes: []rules.ID{1105}},
+			i: issue{level: rules.ErrorLevel, code: 1106, linter: "secrets", ns: "fred"},
+		},
+		"namespace": {
+			p: ExitPolicy{Name: "p", Namespaces: []string{"prod-*"}},
+			i: issue{level: rules.ErrorLevel, linter: "pods", ns: "prod-eu"},
+			e: true,
+		},
+		"namespace-miss": {
+			p: ExitPolicy{Name: "p", Namespaces: []string{"prod-*"}},
+			i: issue{level: rules.ErrorLevel, linter: "pods", ns: "dev"},
+		},
+		"cluster-scoped": {
+			p: ExitPolicy{Name: "p", Namespaces: []string{"prod-*"}},
+			i: issue{level: rules.ErrorLevel, linter: "nodes"},
+		},
+		"linter": {
+			p: ExitPolicy{Name: "p", Level: "warn", Linters: []string{"pods"}},
+			i: issue{level: rules.WarnLevel, linter: "pods", ns: "fred"},
+			e: true,
+		},
+		"linter-miss": {
+			p: ExitPolicy{Name: "p", Level: "warn", Linters: []string{"pods"}},
+			i: issue{level: rules.WarnLevel, linter: "services", ns: "fred"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.p.Matches(u.i.level, u.i.code, u.i.linter, u.i.ns))
+		})
+	}
+}
+
+func TestExitPolicyString(t *testing.T) {
+	uu := map[string]struct {
+		p ExitPolicy
+		e string
+	}{
+		"default": {
+			p: ExitPolicy{Name: "p"},
+			e: "error level issues exceeding 0",
+		},
+		"codes": {
+			p: ExitPolicy{Name: "p", Codes: []rules.ID{1105}},
+			e: "issues with codes POP-1105 exceeding 0",
+		},
+		"full": {
+			p: ExitPolicy{Name: "p", Level: "warn", Linters: []string{"pods"}, Namespaces: []string{"prod-*"}, Baseline: true, Max: 2},
+			e: "warn level issues in linters pods in namespaces prod-* exceeding the baseline by more than 2",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.p.String())
+		})
+	}
+}
+
+func TestExitPoliciesMerge(t *testing.T) {
+	base := ExitPolicies{{Name: "a"}, {Name: "b", Max: 1}}
+	pp := base.Merge(ExitPolicies{{Name: "b", Max: 2}, {Name: "c", Baseline: true}})
+
+	assert.Equal(t, ExitPolicies{{Name: "a"}, {Name: "b", Max: 2}, {Name: "c", Baseline: true}}, pp)
+	assert.True(t, pp.HasBaseline())
+	assert.Equal(t, ExitPolicies{{Name: "a"}, {Name: "b", Max: 1}}, base)
+}
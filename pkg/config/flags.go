@@ -7,10 +7,15 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/derailed/popeye/pkg/storage"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
+// DefaultMarkdownMax caps the number of issues listed in markdown reports.
+const DefaultMarkdownMax = 50
+
 var outputs = []string{
 	"standard",
 	"jurassic",
@@ -18,32 +23,90 @@ var outputs = []string{
 	"json",
 	"html",
 	"junit",
+	"csv",
+	"tsv",
+	"codequality",
+	"markdown",
+	"sarif",
+	"policyreport",
 	"score",
 	"prometheus",
 }
 
+var groupBys = []string{
+	"section",
+	"namespace",
+	"code",
+	"owner",
+}
+
+var splitBys = []string{
+	"team",
+}
+
+// splitOutputs lists the output formats supporting split reports.
+var splitOutputs = []string{
+	"standard",
+	"jurassic",
+	"yaml",
+	"json",
+	"markdown",
+}
+
+var reportVersions = []string{
+	"v1",
+	"v2",
+}
+
+// pivotOutputs lists the output formats supporting report pivots.
+var pivotOutputs = []string{
+	"standard",
+	"jurassic",
+	"yaml",
+	"json",
+	"html",
+}
+
 // Flags represents Popeye CLI flags.
 type Flags struct {
 	*genericclioptions.ConfigFlags
 
 	PushGateway     *PushGateway
+	OTLPEndpoint    *string
 	S3              *S3Info
 	LintLevel       *string
 	Output          *string
 	ClearScreen     *bool
 	Save            *bool
+	History         *bool
+	Events          *bool
+	PolicyReports   *bool
+	MinAge          *string
+	KeepLast        *int
+	KeepFor         *string
 	OutputFile      *string
 	CheckOverAllocs *bool
 	AllNamespaces   *bool
 	Spinach         *string
+	SpinachLayers   *[]string
+	Profile         *string
 	Sections        *[]string
 	InClusterName   *string
 	StandAlone      bool
 	ActiveNamespace *string
 	ForceExitZero   *bool
 	MinScore        *int
+	FailOn          *[]string
+	Baseline        *string
+	MarkdownMax     *int
+	GroupBy         *string
+	SplitBy         *string
+	ReportVersion   *string
 	LogLevel        *int
 	LogFile         *string
+
+	// UsedFlags tracks the command line flags explicitly set for the scan.
+	UsedFlags []string
 }
 
 // NewFlags returns new configuration flags.
@@ -53,17 +116,32 @@ func NewFlags() *Flags {
 		Output:          strPtr("standard"),
 		AllNamespaces:   boolPtr(false),
 		Save:            boolPtr(false),
+		History:         boolPtr(false),
+		Events:          boolPtr(false),
+		PolicyReports:   boolPtr(false),
+		MinAge:          strPtr(""),
+		KeepLast:        intPtr(0),
+		KeepFor:         strPtr(""),
 		OutputFile:      strPtr(""),
 		S3:              newS3Info(),
 		InClusterName:   strPtr(""),
 		ClearScreen:     boolPtr(false),
 		CheckOverAllocs: boolPtr(false),
 		Spinach:         strPtr(""),
+		SpinachLayers:   &[]string{},
+		Profile:         strPtr(""),
 		Sections:        &[]string{},
 		ConfigFlags:     genericclioptions.NewConfigFlags(false),
 		PushGateway:     newPushGateway(),
+		OTLPEndpoint:    strPtr(""),
 		ForceExitZero:   boolPtr(false),
 		MinScore:        intPtr(0),
+		FailOn:          &[]string{},
+		Baseline:        strPtr(""),
+		MarkdownMax:     intPtr(DefaultMarkdownMax),
+		GroupBy:         strPtr("section"),
+		SplitBy:         strPtr(""),
+		ReportVersion:   strPtr("v1"),
 		LogLevel:        intPtr(0),
 		LogFile:         strPtr(""),
 	}
@@ -77,10 +155,80 @@ func (f *Flags) Validate() error {
 		return errors.New("'--save' cannot be used in conjunction with 's3-bucket'")
 	}
 
+	if IsStrSet(f.S3.Bucket) {
+		loc, err := storage.Parse(*f.S3.Bucket)
+		if err != nil {
+			return err
+		}
+		opts, err := f.S3.Options()
+		if err != nil {
+			return err
+		}
+		if err := opts.Check(loc.Kind); err != nil {
+			return err
+		}
+	}
+
 	if !in(outputs, f.Output) {
 		return fmt.Errorf("invalid output format. [%s]", strings.Join(outputs, ","))
 	}
 
+	if f.MarkdownMax != nil && *f.MarkdownMax < 0 {
+		return errors.New("'--markdown-max' must not be negative")
+	}
+
+	if !in(groupBys, f.GroupBy) {
+		return fmt.Errorf("invalid group-by. [%s]", strings.Join(groupBys, ","))
+	}
+	if f.IsPivoted() && !in(pivotOutputs, f.Output) {
+		return fmt.Errorf("'--group-by' is only supported for outputs [%s]", strings.Join(pivotOutputs, ","))
+	}
+
+	if IsStrSet(f.SplitBy) {
+		if !in(splitBys, f.SplitBy) {
+			return fmt.Errorf("invalid split-by. [%s]", strings.Join(splitBys, ","))
+		}
+		if !in(splitOutputs, f.Output) {
+			return fmt.Errorf("'--split-by' is only supported for outputs [%s]", strings.Join(splitOutputs, ","))
+		}
+	}
+
+	if !in(reportVersions, f.ReportVersion) {
+		return fmt.Errorf("invalid report version. [%s]", strings.Join(reportVersions, ","))
+	}
+
+	if IsStrSet(f.MinAge) {
+		if _, err := ParseAge(*f.MinAge); err != nil {
+			return err
+		}
+	}
+
+	if f.KeepLast != nil && *f.KeepLast < 0 {
+		return errors.New("'--keep-last' must not be negative")
+	}
+	if IsStrSet(f.KeepFor) {
+		if _, err := ParseAge(*f.KeepFor); err != nil {
+			return err
+		}
+	}
+	if f.IsRetained() && !IsBoolSet(f.Save) && !IsStrSet(f.S3.Bucket) {
+		return errors.New("'--keep-last' and '--keep-for' must be used in conjunction with '--save' or 's3-bucket'")
+	}
+
+	if f.LintLevel != nil {
+		if _, _, err := parseLintLevel(*f.LintLevel); err != nil {
+			return err
+		}
+	}
+
+	if _, err := f.ExitPolicies(); err != nil {
+		return err
+	}
+
+	if IsStrSet(f.Profile) && !IsProfile(*f.Profile) {
+		return fmt.Errorf("invalid profile. [%s]", strings.Join(Profiles(), ","))
+	}
+
 	if IsStrSet(f.Output) && *f.Output == "prometheus" {
 		if f.PushGateway == nil || !IsStrSet(f.PushGateway.URL) {
 			return errors.New("you must set --push-gtwy-url when prometheus report is enabled")
@@ -94,6 +242,89 @@ func (f *Flags) IsPersistent() bool {
 	return IsBoolSet(f.Save) || IsStrSet(f.OutputFile) || (f.S3 != nil && IsStrSet(f.S3.Bucket))
 }
 
+// IsPivoted returns true if the report is grouped by anything other than linter sections.
+func (f *Flags) IsPivoted() bool {
+	return IsStrSet(f.GroupBy) && *f.GroupBy != "section"
+}
+
+// IsSplit returns true if one report per team should be produced.
+func (f *Flags) IsSplit() bool {
+	return IsStrSet(f.SplitBy)
+}
+
+// spinachFiles returns the spinach files to layer in order. The spinach file
+// comes first followed by the additional layers.
+func (f *Flags) spinachFiles() []string {
+	var ff []string
+	if IsStrSet(f.Spinach) {
+		ff = append(ff, *f.Spinach)
+	}
+	if f.SpinachLayers != nil {
+		for _, l := range *f.SpinachLayers {
+			if l != "" {
+				ff = append(ff, l)
+			}
+		}
+	}
+
+	return ff
+}
+
+// IsLegacyReport returns true if json and yaml reports should use the v1 shape.
+func (f *Flags) IsLegacyReport() bool {
+	return f.ReportVersion == nil || *f.ReportVersion != "v2"
+}
+
+// ExitPolicies returns the exit policies given on the command line.
+func (f *Flags) ExitPolicies() (ExitPolicies, error) {
+	if f.FailOn == nil {
+		return nil, nil
+	}
+	pp := make(ExitPolicies, 0, len(*f.FailOn))
+	for _, spec := range *f.FailOn {
+		p, err := ParseExitPolicy(spec)
+		if err != nil {
+			return nil, err
+		}
+		pp = append(pp, p)
+	}
+
+	return pp, nil
+}
+
+// TracksFindings returns true if issues first seen times must be tracked.
+func (f *Flags) TracksFindings() bool {
+	return IsBoolSet(f.History) || IsStrSet(f.MinAge)
+}
+
+// MinIssueAge returns the minimum age of reported issues.
+func (f *Flags) MinIssueAge() time.Duration {
+	if !IsStrSet(f.MinAge) {
+		return 0
+	}
+	age, _ := ParseAge(*f.MinAge)
+
+	return age
+}
+
+// IsRetained returns true if archived reports are subject to retention.
+func (f *Flags) IsRetained() bool {
+	return (f.KeepLast != nil && *f.KeepLast > 0) || IsStrSet(f.KeepFor)
+}
+
+// Retention returns the archived reports retention rules.
+func (f *Flags) Retention() storage.Retention {
+	var r storage.Retention
+	if f.KeepLast != nil {
+		r.Keep = *f.KeepLast
+	}
+	if IsStrSet(f.KeepFor) {
+		r.MaxAge, _ = ParseAge(*f.KeepFor)
+	}
+
+	return r
+}
+
 // OutputFormat returns the report output format.
 func (f *Flags) OutputFormat() string {
 	if f.Output != nil && *f.Output != "" {
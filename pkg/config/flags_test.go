@@ -5,7 +5,10 @@ package config
 
 import (
 	"testing"
+	"time"
 
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/derailed/popeye/pkg/storage"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -104,3 +107,267 @@ func TestOutputFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSplitBy(t *testing.T) {
+	uu := map[string]struct {
+		f   Flags
+		err string
+	}{
+		"default": {
+			f: Flags{S3: &S3Info{}, SplitBy: strPtr("")},
+		},
+		"team": {
+			f: Flags{S3: &S3Info{}, SplitBy: strPtr("team"), Output: strPtr("json")},
+		},
+		"toast": {
+			f:   Flags{S3: &S3Info{}, SplitBy: strPtr("toast")},
+			err: "invalid split-by. [team]",
+		},
+		"unsupported-output": {
+			f:   Flags{S3: &S3Info{}, SplitBy: strPtr("team"), Output: strPtr("html")},
+			err: "'--split-by' is only supported for outputs [standard,jurassic,yaml,json,markdown]",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			err := u.f.Validate()
+			if u.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, u.err)
+		})
+	}
+}
+
+func TestValidateGroupBy(t *testing.T) {
+	uu := map[string]struct {
+		f   Flags
+		err string
+	}{
+		"default": {
+			f: Flags{S3: &S3Info{}},
+		},
+		"namespace": {
+			f: Flags{S3: &S3Info{}, GroupBy: strPtr("namespace"), Output: strPtr("json")},
+		},
+		"toast": {
+			f:   Flags{S3: &S3Info{}, GroupBy: strPtr("toast")},
+			err: "invalid group-by. [section,namespace,code,owner]",
+		},
+		"unsupported-output": {
+			f:   Flags{S3: &S3Info{}, GroupBy: strPtr("owner"), Output: strPtr("junit")},
+			err: "'--group-by' is only supported for outputs [standard,jurassic,yaml,json,html]",
+		},
+		"section-any-output": {
+			f: Flags{S3: &S3Info{}, GroupBy: strPtr("section"), Output: strPtr("junit")},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			err := u.f.Validate()
+			if u.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, u.err)
+		})
+	}
+}
+
+func TestValidateReportVersion(t *testing.T) {
+	uu := map[string]struct {
+		v   string
+		err string
+	}{
+		"v1": {v: "v1"},
+		"v2": {v: "v2"},
+		"toast": {
+			v:   "v3",
+			err: "invalid report version. [v1,v2]",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			f := Flags{S3: &S3Info{}, ReportVersion: strPtr(u.v)}
+			err := f.Validate()
+			if u.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, u.v == "v1", f.IsLegacyReport())
+				return
+			}
+			assert.EqualError(t, err, u.err)
+		})
+	}
+}
+
+func TestReportVersionDefault(t *testing.T) {
+	assert.True(t, NewFlags().IsLegacyReport())
+}
+
+func TestValidateMinAge(t *testing.T) {
+	uu := map[string]struct {
+		age string
+		e   time.Duration
+		err string
+	}{
+		"none": {},
+		"days": {age: "14d", e: 14 * 24 * time.Hour},
+		"toast": {
+			age: "fred",
+			err: `invalid age "fred". Use days ie 7d or a duration ie 12h`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			f := Flags{S3: &S3Info{}, MinAge: strPtr(u.age)}
+			err := f.Validate()
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, u.e, f.MinIssueAge())
+			assert.Equal(t, u.age != "", f.TracksFindings())
+		})
+	}
+}
+
+func TestValidateStorage(t *testing.T) {
+	uu := map[string]struct {
+		uri  string
+		tags []string
+		sse  string
+		e    map[string]string
+		err  string
+	}{
+		"none": {},
+		"gcs": {
+			uri:  "gs://fred/blee",
+			tags: []string{"team=ops", "env="},
+			e:    map[string]string{"team": "ops", "env": ""},
+		},
+		"bad-uri": {
+			uri: "ftp://fred",
+			err: `invalid storage URI: "ftp://fred"`,
+		},
+		"bad-tag": {
+			uri:  "s3://fred",
+			tags: []string{"team"},
+			err:  `invalid storage tag "team". Use key=value`,
+		},
+		"unsupported": {
+			uri: "gs://fred",
+			sse: "AES256",
+			err: "gs storage does not support options: sse",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			s3 := newS3Info()
+			s3.Bucket, s3.Tags, s3.SSE = strPtr(u.uri), &u.tags, strPtr(u.sse)
+			f := Flags{S3: s3}
+			err := f.Validate()
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			assert.NoError(t, err)
+			tt, err := f.S3.ObjectTags()
+			assert.NoError(t, err)
+			assert.Equal(t, u.e, tt)
+		})
+	}
+}
+
+func TestValidateRetention(t *testing.T) {
+	uu := map[string]struct {
+		f   Flags
+		e   storage.Retention
+		err string
+	}{
+		"none": {
+			f: Flags{S3: &S3Info{}},
+		},
+		"save": {
+			f: Flags{S3: &S3Info{}, Save: boolPtr(true), KeepLast: intPtr(5), KeepFor: strPtr("30d")},
+			e: storage.Retention{Keep: 5, MaxAge: 30 * 24 * time.Hour},
+		},
+		"bucket": {
+			f: Flags{S3: &S3Info{Bucket: strPtr("s3://fred")}, KeepLast: intPtr(5)},
+			e: storage.Retention{Keep: 5},
+		},
+		"negative": {
+			f:   Flags{S3: &S3Info{}, Save: boolPtr(true), KeepLast: intPtr(-1)},
+			err: "'--keep-last' must not be negative",
+		},
+		"bad-age": {
+			f:   Flags{S3: &S3Info{}, Save: boolPtr(true), KeepFor: strPtr("fred")},
+			err: `invalid age "fred". Use days ie 7d or a duration ie 12h`,
+		},
+		"not-saved": {
+			f:   Flags{S3: &S3Info{}, KeepFor: strPtr("7d")},
+			err: "'--keep-last' and '--keep-for' must be used in conjunction with '--save' or 's3-bucket'",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			err := u.f.Validate()
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, u.e, u.f.Retention())
+		})
+	}
+}
+
+func TestValidateFailOn(t *testing.T) {
+	uu := map[string]struct {
+		f   Flags
+		e   ExitPolicies
+		err string
+	}{
+		"none": {
+			f: Flags{S3: &S3Info{}},
+		},
+		"policies": {
+			f: Flags{S3: &S3Info{}, FailOn: &[]string{"level=error,namespace=prod-*", "name=dangling,code=1105"}},
+			e: ExitPolicies{
+				{Name: "level=error,namespace=prod-*", Level: "error", Namespaces: []string{"prod-*"}},
+				{Name: "dangling", Codes: []rules.ID{1105}},
+			},
+		},
+		"toast": {
+			f:   Flags{S3: &S3Info{}, FailOn: &[]string{"level=fatal"}},
+			err: `exit policy "level=fatal" invalid level "fatal". [ok,info,warn,error]`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			err := u.f.Validate()
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			assert.NoError(t, err)
+			pp, _ := u.f.ExitPolicies()
+			assert.Equal(t, u.e, pp)
+		})
+	}
+}
@@ -3,10 +3,33 @@
 
 package config
 
-import "regexp"
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
 
 var invalidPathCharsRX = regexp.MustCompile(`[:/]+`)
 
+// ParseAge parses an age using either a day count ie 7d or a duration ie 12h.
+func ParseAge(s string) (time.Duration, error) {
+	if d, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(d)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age %q. Use days ie 7d or a duration ie 12h", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	age, err := time.ParseDuration(s)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("invalid age %q. Use days ie 7d or a duration ie 12h", s)
+	}
+
+	return age, nil
+}
+
 func in(oo []string, p *string) bool {
 	if p == nil {
 		return true
@@ -35,6 +58,14 @@ func IsBoolSet(s *bool) bool {
 	return s != nil && *s
 }
 
+func strOf(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
 func boolPtr(b bool) *bool {
 	return &b
 }
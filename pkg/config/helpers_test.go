@@ -5,6 +5,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -78,3 +79,36 @@ func TestSanitizeFile(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAge(t *testing.T) {
+	uu := map[string]struct {
+		s   string
+		e   time.Duration
+		err string
+	}{
+		"days":     {s: "7d", e: 7 * 24 * time.Hour},
+		"duration": {s: "12h", e: 12 * time.Hour},
+		"zero":     {s: "0d"},
+		"toast": {
+			s:   "7w",
+			err: `invalid age "7w". Use days ie 7d or a duration ie 12h`,
+		},
+		"negative": {
+			s:   "-1d",
+			err: `invalid age "-1d". Use days ie 7d or a duration ie 12h`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			age, err := ParseAge(u.s)
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, u.e, age)
+		})
+	}
+}
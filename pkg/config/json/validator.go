@@ -15,12 +15,20 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// SpinachSchema describes spinach schema.
-const SpinachSchema = "spinach.json"
+const (
+	// SpinachSchema describes spinach schema.
+	SpinachSchema = "spinach.json"
+
+	// ReportSchema describes the structured scan report schema.
+	ReportSchema = "report-v2.json"
+)
 
 var (
 	//go:embed schemas/spinach.json
 	spinachSchema string
+
+	//go:embed schemas/report-v2.json
+	reportSchema string
 )
 
 // Validator tracks schemas validation.
@@ -34,6 +42,7 @@ func NewValidator() *Validator {
 	v := Validator{
 		schemas: map[string]gojsonschema.JSONLoader{
 			SpinachSchema: gojsonschema.NewStringLoader(spinachSchema),
+			ReportSchema:  gojsonschema.NewStringLoader(reportSchema),
 		},
 	}
 	v.register()
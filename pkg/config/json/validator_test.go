@@ -19,6 +19,12 @@ func TestValidateSpinach(t *testing.T) {
 		"happy": {
 			f: "testdata/1.yaml",
 		},
+		"exit-policies": {
+			f: "testdata/exit.yaml",
+		},
+		"teams": {
+			f: "testdata/teams.yaml",
+		},
 		"toast": {
 			f:   "testdata/toast.yaml",
 			err: "Additional property rbac.authorization.k8s.io/v1/clusterroles is not allowed",
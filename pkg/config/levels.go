@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/derailed/popeye/internal/rules"
+)
+
+var lintLevels = []string{"ok", "info", "warn", "error"}
+
+// LintLevels tracks per linter minimum lint levels.
+type LintLevels map[string]string
+
+// Merge returns the given levels layered on a copy of this set.
+func (ll LintLevels) Merge(o LintLevels) LintLevels {
+	return rules.MergeMap(ll, o)
+}
+
+func (ll LintLevels) toLevels() map[string]rules.Level {
+	mm := make(map[string]rules.Level, len(ll))
+	for k, v := range ll {
+		mm[k] = rules.ToIssueLevel(&v)
+	}
+
+	return mm
+}
+
+// parseLintLevel parses a lint level spec ie `warn,nodes=error,secrets=info`.
+// Bare levels set the global lint level while k=v pairs set a level per linter.
+func parseLintLevel(spec string) (string, LintLevels, error) {
+	var (
+		global string
+		ll     LintLevels
+	)
+	for _, t := range strings.Split(spec, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		linter, level, ok := strings.Cut(t, "=")
+		if !ok {
+			if !slices.Contains(lintLevels, t) {
+				return "", nil, fmt.Errorf("invalid lint level %q. [%s]", t, strings.Join(lintLevels, ","))
+			}
+			global = t
+			continue
+		}
+		linter, level = strings.TrimSpace(linter), strings.TrimSpace(level)
+		if linter == "" || !slices.Contains(lintLevels, level) {
+			return "", nil, fmt.Errorf("invalid linter lint level %q. [%s]", t, strings.Join(lintLevels, ","))
+		}
+		if ll == nil {
+			ll = make(LintLevels)
+		}
+		ll[linter] = level
+	}
+
+	return global, ll, nil
+}
@@ -11,7 +11,7 @@ const (
 // Limits tracks cpu and mem limits.
 type Limits struct {
 	CPU    float64 `yaml:"cpu"`
-	Memory float64 `yam:"memory"`
+	Memory float64 `yaml:"memory"`
 }
 
 // Node tracks node configurations.
@@ -28,3 +28,12 @@ func newNode() Node {
 		},
 	}
 }
+
+func (l *Limits) merge(o Limits) {
+	if o.CPU != 0 {
+		l.CPU = o.CPU
+	}
+	if o.Memory != 0 {
+		l.Memory = o.Memory
+	}
+}
@@ -4,6 +4,8 @@
 package config
 
 import (
+	"slices"
+
 	"github.com/derailed/popeye/internal/rules"
 )
 
@@ -24,7 +26,7 @@ type (
 	// Allocations track under/over allocation limits.
 	Allocations struct {
 		UnderPerc int `yaml:"underPercUtilization"`
-		OverPerc  int `yanl:"overPercUtilization"`
+		OverPerc  int `yaml:"overPercUtilization"`
 	}
 
 	Resources struct {
@@ -44,10 +46,25 @@ type (
 		Resources Resources `yaml:"resources"`
 
 		// Codes provides to override codes severity.
-		Overrides rules.Overrides `yaml:"overrides"`
+		Overrides rules.Overrides `yaml:"overrides,omitempty"`
 
 		// Registries tracks allowed docker registries.
-		Registries []string `yaml:"registries"`
+		Registries []string `yaml:"registries,omitempty"`
+
+		// LintLevels tracks minimum lint levels per linter.
+		LintLevels LintLevels `yaml:"lintLevels,omitempty"`
+
+		// Scoring tracks the linter and cluster scoring model.
+		Scoring Scoring `yaml:"scoring,omitempty"`
+
+		// Webhooks tracks scan notification endpoints.
+		Webhooks Webhooks `yaml:"webhooks,omitempty"`
+
+		// ExitPolicies tracks the conditions failing the scan.
+		ExitPolicies ExitPolicies `yaml:"exitPolicies,omitempty"`
+
+		// Teams tracks resource ownership by team.
+		Teams Teams `yaml:"teams,omitempty"`
 	}
 )
 
@@ -75,3 +92,41 @@ func NewPopeye() Popeye {
 func (p Popeye) Match(spec rules.Spec) bool {
 	return p.Exclusions.Match(spec)
 }
+
+// Merge layers the given configuration on top of this one. Exclusions are
+// appended, thresholds and overrides replace existing ones and registries are
+// unioned.
+func (p *Popeye) Merge(o Popeye) {
+	p.CPU.merge(o.CPU)
+	p.MEM.merge(o.MEM)
+	p.Exclusions.Merge(o.Exclusions)
+	p.Resources.merge(o.Resources)
+	p.Overrides = p.Overrides.Merge(o.Overrides)
+	p.LintLevels = p.LintLevels.Merge(o.LintLevels)
+	p.Scoring.Merge(o.Scoring)
+	p.Webhooks = p.Webhooks.Merge(o.Webhooks)
+	p.ExitPolicies = p.ExitPolicies.Merge(o.ExitPolicies)
+	p.Teams.Merge(o.Teams)
+	for _, r := range o.Registries {
+		if !slices.Contains(p.Registries, r) {
+			p.Registries = append(p.Registries, r)
+		}
+	}
+}
+
+func (a *Allocations) merge(o Allocations) {
+	if o.UnderPerc != 0 {
+		a.UnderPerc = o.UnderPerc
+	}
+	if o.OverPerc != 0 {
+		a.OverPerc = o.OverPerc
+	}
+}
+
+func (r *Resources) merge(o Resources) {
+	r.Node.Limits.merge(o.Node.Limits)
+	r.Pod.Limits.merge(o.Pod.Limits)
+	if o.Pod.Restarts != 0 {
+		r.Pod.Restarts = o.Pod.Restarts
+	}
+}
@@ -30,3 +30,72 @@ func TestNewPopeye(t *testing.T) {
 	})
 	assert.False(t, ok)
 }
+
+func TestPopeyeMergeKeepsLowerLayer(t *testing.T) {
+	const (
+		lower = `
+popeye:
+  excludes:
+    global:
+      fqns: [ns1]
+      labels:
+        app: [fred]
+    linters:
+      pods:
+        codes: ["100"]
+  overrides:
+  - code: 100
+    severity: 1
+  lintLevels:
+    pods: warn
+  scoring:
+    linters:
+      pods: 2
+    grades:
+      A: 95
+`
+		upper = `
+popeye:
+  excludes:
+    global:
+      fqns: [ns2]
+      labels:
+        app: [blee]
+    linters:
+      pods:
+        codes: ["101"]
+      nodes:
+        codes: ["700"]
+  overrides:
+  - code: 100
+    severity: 3
+  - code: 101
+    severity: 2
+  lintLevels:
+    pods: error
+    nodes: info
+  scoring:
+    linters:
+      pods: 3
+    grades:
+      A: 98
+`
+	)
+
+	parse := func(raw string) Popeye {
+		sp, err := parseSpinach("test", []byte(raw))
+		assert.NoError(t, err)
+		return sp.Popeye
+	}
+	base := parse(lower)
+	p := base
+	p.Merge(parse(upper))
+
+	assert.Equal(t, parse(lower), base)
+	assert.Equal(t, 2, len(p.Exclusions.Global.FQNs))
+	assert.Equal(t, 2, len(p.Exclusions.Linters["pods"].Codes))
+	assert.Equal(t, rules.Overrides{{ID: 100, Severity: 3}, {ID: 101, Severity: 2}}, p.Overrides)
+	assert.Equal(t, LintLevels{"pods": "error", "nodes": "info"}, p.LintLevels)
+	assert.Equal(t, 3.0, p.Scoring.Weight("pods"))
+	assert.Equal(t, 98, p.Scoring.GradeCutoffs()["A"])
+}
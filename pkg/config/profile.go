@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package config
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+const profileExt = ".yml"
+
+//go:embed profiles/*.yml
+var profiles embed.FS
+
+// Profiles returns the list of built-in spinach profiles.
+func Profiles() []string {
+	ee, err := profiles.ReadDir("profiles")
+	if err != nil {
+		return nil
+	}
+	nn := make([]string, 0, len(ee))
+	for _, e := range ee {
+		nn = append(nn, strings.TrimSuffix(e.Name(), profileExt))
+	}
+	slices.Sort(nn)
+
+	return nn
+}
+
+// IsProfile checks if a built-in profile exists.
+func IsProfile(n string) bool {
+	return slices.Contains(Profiles(), n)
+}
+
+// loadProfile layers a built-in profile onto the given configuration.
+func loadProfile(n string, p *Popeye) error {
+	if !IsProfile(n) {
+		return fmt.Errorf("invalid profile %q. [%s]", n, strings.Join(Profiles(), ","))
+	}
+	file := path.Join("profiles", n+profileExt)
+	bb, err := profiles.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	sp, err := parseSpinach(file, bb)
+	if err != nil {
+		return err
+	}
+	p.Merge(sp.Popeye)
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package config
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/derailed/popeye/internal/rules"
+)
+
+// defaultPenalties tracks the default score penalty of a resource given its
+// highest issue severity. By default resources with warnings or errors count
+// as failed.
+var defaultPenalties = map[string]float64{
+	"ok":    0,
+	"info":  0,
+	"warn":  1,
+	"error": 1,
+}
+
+// grades tracks the grades from best to worst.
+var grades = []string{"A", "B", "C", "D", "E"}
+
+// defaultGrades tracks the default minimum score per grade.
+var defaultGrades = map[string]int{
+	"A": 90,
+	"B": 80,
+	"C": 70,
+	"D": 60,
+	"E": 50,
+}
+
+// Scoring tracks the scoring model used to compute linter and cluster scores.
+type Scoring struct {
+	// Severities tracks the penalty (0-1) of a resource given its highest issue severity.
+	Severities map[string]float64 `yaml:"severities,omitempty"`
+
+	// Linters tracks linter weights used to compute the cluster score. Defaults to 1.
+	Linters map[string]float64 `yaml:"linters,omitempty"`
+
+	// Grades tracks the minimum score for each grade. Anything below is an F.
+	Grades map[string]int `yaml:"grades,omitempty"`
+
+	// IncludeEmpty indicates whether linters with no resources count toward the cluster score.
+	IncludeEmpty *bool `yaml:"includeEmpty,omitempty"`
+}
+
+// Merge layers the given scoring on top of this one.
+func (s *Scoring) Merge(o Scoring) {
+	s.Severities = rules.MergeMap(s.Severities, o.Severities)
+	s.Linters = rules.MergeMap(s.Linters, o.Linters)
+	s.Grades = rules.MergeMap(s.Grades, o.Grades)
+	if o.IncludeEmpty != nil {
+		s.IncludeEmpty = o.IncludeEmpty
+	}
+}
+
+// IsCustom returns true if the default scoring model was amended.
+func (s Scoring) IsCustom() bool {
+	return len(s.Severities) > 0 || len(s.Linters) > 0 || len(s.Grades) > 0 || s.IncludeEmpty != nil
+}
+
+// Penalty returns the score penalty for a given severity.
+func (s Scoring) Penalty(l rules.Level) float64 {
+	k := l.ToHumanLevel()
+	if p, ok := s.Severities[k]; ok {
+		return p
+	}
+
+	return defaultPenalties[k]
+}
+
+// Penalties returns the effective penalties per severity.
+func (s Scoring) Penalties() map[string]float64 {
+	return rules.MergeMap(maps.Clone(defaultPenalties), s.Severities)
+}
+
+// Weight returns the cluster score weight of a given linter.
+func (s Scoring) Weight(linter string) float64 {
+	if w, ok := s.Linters[linter]; ok {
+		return w
+	}
+
+	return 1
+}
+
+// GradeCutoffs returns the effective minimum score per grade.
+func (s Scoring) GradeCutoffs() map[string]int {
+	return rules.MergeMap(maps.Clone(defaultGrades), s.Grades)
+}
+
+// Validate checks the effective grade cutoffs strictly decrease from A to E.
+func (s Scoring) Validate() error {
+	cc := s.GradeCutoffs()
+	for i := 1; i < len(grades); i++ {
+		prev, cur := grades[i-1], grades[i]
+		if cc[cur] >= cc[prev] {
+			return fmt.Errorf("invalid scoring grades: %s (%d) must be below %s (%d)", cur, cc[cur], prev, cc[prev])
+		}
+	}
+
+	return nil
+}
+
+// CountEmpty returns true if linters without resources count toward the cluster score.
+func (s Scoring) CountEmpty() bool {
+	return s.IncludeEmpty == nil || *s.IncludeEmpty
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package config
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/internal/rules"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoringPenalty(t *testing.T) {
+	uu := map[string]struct {
+		s rules.Level
+		c Scoring
+		e float64
+	}{
+		"default-ok": {
+			s: rules.OkLevel,
+		},
+		"default-warn": {
+			s: rules.WarnLevel,
+			e: 1,
+		},
+		"custom": {
+			s: rules.WarnLevel,
+			c: Scoring{Severities: map[string]float64{"warn": 0.5}},
+			e: 0.5,
+		},
+		"custom-other": {
+			s: rules.ErrorLevel,
+			c: Scoring{Severities: map[string]float64{"warn": 0.5}},
+			e: 1,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.c.Penalty(u.s))
+		})
+	}
+}
+
+func TestScoringMerge(t *testing.T) {
+	no := false
+	s := Scoring{
+		Linters: map[string]float64{"pods": 2},
+		Grades:  map[string]int{"A": 95},
+	}
+	s.Merge(Scoring{
+		Linters:      map[string]float64{"secrets": 3},
+		Grades:       map[string]int{"A": 98},
+		IncludeEmpty: &no,
+	})
+
+	assert.True(t, s.IsCustom())
+	assert.Equal(t, 2.0, s.Weight("pods"))
+	assert.Equal(t, 3.0, s.Weight("secrets"))
+	assert.Equal(t, 1.0, s.Weight("nodes"))
+	assert.Equal(t, map[string]int{"A": 98, "B": 80, "C": 70, "D": 60, "E": 50}, s.GradeCutoffs())
+	assert.False(t, s.CountEmpty())
+	assert.Equal(t, map[string]float64{"ok": 0, "info": 0, "warn": 1, "error": 1}, s.Penalties())
+}
+
+func TestScoringDefaults(t *testing.T) {
+	var s Scoring
+
+	assert.False(t, s.IsCustom())
+	assert.True(t, s.CountEmpty())
+	assert.Equal(t, defaultGrades, s.GradeCutoffs())
+}
+
+func TestScoringValidate(t *testing.T) {
+	uu := map[string]struct {
+		c   Scoring
+		err string
+	}{
+		"defaults": {},
+		"custom": {
+			c: Scoring{Grades: map[string]int{"A": 95, "B": 85}},
+		},
+		"inverted": {
+			c:   Scoring{Grades: map[string]int{"B": 95}},
+			err: "invalid scoring grades: B (95) must be below A (90)",
+		},
+		"equal": {
+			c:   Scoring{Grades: map[string]int{"D": 70}},
+			err: "invalid scoring grades: D (70) must be below C (70)",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			err := u.c.Validate()
+			if u.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, u.err)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/derailed/popeye/pkg/config/json"
+	"gopkg.in/yaml.v2"
+)
+
+// Spinach represents a spinach configuration file.
+type Spinach struct {
+	// Include tracks spinach fragments layered under this file.
+	Include []string `yaml:"include,omitempty"`
+
+	// Popeye tracks Popeye configuration options.
+	Popeye Popeye `yaml:"popeye"`
+}
+
+// spinachLoader layers spinach files and their includes.
+type spinachLoader struct {
+	visiting map[string]struct{}
+	loaded   map[string]struct{}
+}
+
+func newSpinachLoader() *spinachLoader {
+	return &spinachLoader{
+		visiting: make(map[string]struct{}),
+		loaded:   make(map[string]struct{}),
+	}
+}
+
+// load reads a spinach file and layers it with its includes onto the given
+// configuration. Includes are merged first in order of declaration, followed by
+// the file itself. A given file is only ever merged once.
+func (l *spinachLoader) load(path string, p *Popeye) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if _, ok := l.visiting[abs]; ok {
+		return fmt.Errorf("spinach include cycle detected on %q", path)
+	}
+	if _, ok := l.loaded[abs]; ok {
+		return nil
+	}
+
+	bb, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	sp, err := parseSpinach(path, bb)
+	if err != nil {
+		return err
+	}
+
+	l.visiting[abs] = struct{}{}
+	for _, inc := range sp.Include {
+		if !filepath.IsAbs(inc) {
+			inc = filepath.Join(filepath.Dir(path), inc)
+		}
+		if err := l.load(inc, p); err != nil {
+			return fmt.Errorf("include failed for %q: %w", path, err)
+		}
+	}
+	delete(l.visiting, abs)
+	l.loaded[abs] = struct{}{}
+	p.Merge(sp.Popeye)
+
+	return nil
+}
+
+func parseSpinach(name string, bb []byte) (Spinach, error) {
+	var sp Spinach
+	if err := json.NewValidator().Validate(json.SpinachSchema, bb); err != nil {
+		return sp, fmt.Errorf("validation failed for %q: %w", name, err)
+	}
+	if err := yaml.Unmarshal(bb, &sp); err != nil {
+		return sp, fmt.Errorf("Invalid spinach config file -- %w", err)
+	}
+
+	return sp, nil
+}
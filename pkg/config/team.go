@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/derailed/popeye/internal/rules"
+)
+
+// Unowned represents resources not owned by any team.
+const Unowned = "unowned"
+
+// Teams tracks resource ownership. Teams are resolved from object labels
+// first, then namespace labels and finally namespace names.
+type Teams struct {
+	// Label the object or namespace label naming the owning team ie team.
+	Label string `yaml:"label,omitempty"`
+
+	// Mappings tracks explicit team ownership rules.
+	Mappings []TeamMapping `yaml:"mappings,omitempty"`
+}
+
+// TeamMapping maps resources to a team.
+type TeamMapping struct {
+	// Team the owning team name.
+	Team string `yaml:"team"`
+
+	// Namespaces the namespace names or rx: regexes owned by the team.
+	Namespaces []string `yaml:"namespaces,omitempty"`
+
+	// NamespaceLabels the labels selecting namespaces owned by the team.
+	NamespaceLabels map[string]string `yaml:"namespaceLabels,omitempty"`
+
+	// Labels the labels selecting objects owned by the team.
+	Labels map[string]string `yaml:"labels,omitempty"`
+}
+
+// IsSet returns true if team ownership is configured.
+func (t Teams) IsSet() bool {
+	return t.Label != "" || len(t.Mappings) > 0
+}
+
+// Merge layers the given teams on top of this one. Mappings for the same team
+// are replaced.
+func (t *Teams) Merge(o Teams) {
+	if o.Label != "" {
+		t.Label = o.Label
+	}
+	t.Mappings = rules.MergeBy(t.Mappings, o.Mappings, func(m TeamMapping) string { return m.Team })
+}
+
+// Validate checks the teams configuration.
+func (t Teams) Validate() error {
+	for _, m := range t.Mappings {
+		if m.Team == "" {
+			return fmt.Errorf("team mapping name is required")
+		}
+		if m.Team == Unowned {
+			return fmt.Errorf("team %q is reserved", Unowned)
+		}
+		if len(m.Namespaces) == 0 && len(m.NamespaceLabels) == 0 && len(m.Labels) == 0 {
+			return fmt.Errorf("team %q requires namespaces, namespaceLabels or labels", m.Team)
+		}
+		for _, ns := range m.Namespaces {
+			if !rules.Expression(ns).IsRX() {
+				continue
+			}
+			if _, err := regexp.Compile(strings.Replace(ns, "rx:", "", 1)); err != nil {
+				return fmt.Errorf("team %q invalid namespace regex %q", m.Team, ns)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Owner returns the team owning a resource given its namespace and labels.
+func (t Teams) Owner(ns string, nsLabels, labels map[string]string) string {
+	if team := t.byLabels(labels, func(m TeamMapping) map[string]string { return m.Labels }); team != "" {
+		return team
+	}
+	if ns == "" {
+		return Unowned
+	}
+	if team := t.byLabels(nsLabels, func(m TeamMapping) map[string]string { return m.NamespaceLabels }); team != "" {
+		return team
+	}
+	for _, m := range t.Mappings {
+		for _, exp := range m.Namespaces {
+			e := rules.Expression(exp)
+			if (e.IsRX() && e.MatchRX(ns)) || exp == ns {
+				return m.Team
+			}
+		}
+	}
+
+	return Unowned
+}
+
+func (t Teams) byLabels(labels map[string]string, sel func(TeamMapping) map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	for _, m := range t.Mappings {
+		if ss := sel(m); len(ss) > 0 && hasLabels(labels, ss) {
+			return m.Team
+		}
+	}
+	if t.Label != "" {
+		return labels[t.Label]
+	}
+
+	return ""
+}
+
+func hasLabels(labels, sel map[string]string) bool {
+	for k, v := range sel {
+		if l, ok := labels[k]; !ok || l != v {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamsOwner(t *testing.T) {
+	tt := Teams{
+		Label: "team",
+		Mappings: []TeamMapping{
+			{Team: "payments", Namespaces: []string{"billing", "rx:^pay-"}},
+			{Team: "search", NamespaceLabels: map[string]string{"app.kubernetes.io/part-of": "search"}},
+			{Team: "infra", Labels: map[string]string{"tier": "infra"}},
+		},
+	}
+
+	uu := map[string]struct {
+		ns               string
+		nsLabels, labels map[string]string
+		e                string
+	}{
+		"ns-name": {
+			ns: "billing",
+			e:  "payments",
+		},
+		"ns-rx": {
+			ns: "pay-eu",
+			e:  "payments",
+		},
+		"ns-label-mapping": {
+			ns:       "fred",
+			nsLabels: map[string]string{"app.kubernetes.io/part-of": "search"},
+			e:        "search",
+		},
+		"ns-label": {
+			ns:       "billing",
+			nsLabels: map[string]string{"team": "checkout"},
+			e:        "checkout",
+		},
+		"object-label-mapping": {
+			ns:     "billing",
+			labels: map[string]string{"tier": "infra", "team": "blee"},
+			e:      "infra",
+		},
+		"object-label": {
+			ns:       "billing",
+			nsLabels: map[string]string{"team": "checkout"},
+			labels:   map[string]string{"team": "blee"},
+			e:        "blee",
+		},
+		"cluster-scoped": {
+			labels: map[string]string{"team": "blee"},
+			e:      "blee",
+		},
+		"unowned": {
+			ns:     "fred",
+			labels: map[string]string{"app": "fred"},
+			e:      Unowned,
+		},
+		"cluster-unowned": {
+			e: Unowned,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, tt.Owner(u.ns, u.nsLabels, u.labels))
+		})
+	}
+}
+
+func TestTeamsValidate(t *testing.T) {
+	uu := map[string]struct {
+		t   Teams
+		err string
+	}{
+		"label": {
+			t: Teams{Label: "team"},
+		},
+		"mapping": {
+			t: Teams{Mappings: []TeamMapping{{Team: "payments", Namespaces: []string{"rx:^pay-"}}}},
+		},
+		"no-name": {
+			t:   Teams{Mappings: []TeamMapping{{Namespaces: []string{"fred"}}}},
+			err: "team mapping name is required",
+		},
+		"reserved": {
+			t:   Teams{Mappings: []TeamMapping{{Team: Unowned, Namespaces: []string{"fred"}}}},
+			err: `team "unowned" is reserved`,
+		},
+		"no-selector": {
+			t:   Teams{Mappings: []TeamMapping{{Team: "payments"}}},
+			err: `team "payments" requires namespaces, namespaceLabels or labels`,
+		},
+		"regex": {
+			t:   Teams{Mappings: []TeamMapping{{Team: "payments", Namespaces: []string{"rx:^pay-("}}}},
+			err: `team "payments" invalid namespace regex "rx:^pay-("`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			err := u.t.Validate()
+			if u.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, u.err)
+		})
+	}
+}
+
+func TestTeamsMerge(t *testing.T) {
+	base := Teams{
+		Label:    "team",
+		Mappings: []TeamMapping{{Team: "payments", Namespaces: []string{"billing"}}},
+	}
+	tt := base
+	tt.Merge(Teams{
+		Mappings: []TeamMapping{
+			{Team: "payments", Namespaces: []string{"pay"}},
+			{Team: "search", Namespaces: []string{"search"}},
+		},
+	})
+
+	assert.Equal(t, Teams{
+		Label: "team",
+		Mappings: []TeamMapping{
+			{Team: "payments", Namespaces: []string{"pay"}},
+			{Team: "search", Namespaces: []string{"search"}},
+		},
+	}, tt)
+	assert.Equal(t, []TeamMapping{{Team: "payments", Namespaces: []string{"billing"}}}, base.Mappings)
+}
@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/derailed/popeye/internal/rules"
+)
+
+const (
+	// WebhookJSON posts the scan summary as JSON.
+	WebhookJSON = "json"
+	// WebhookSlack posts a Slack incoming webhook message.
+	WebhookSlack = "slack"
+	// WebhookTeams posts a Microsoft Teams message card.
+	WebhookTeams = "teams"
+
+	// OnAlways notifies after every scan.
+	OnAlways = "always"
+	// OnScoreDrop notifies when the score dropped since the last scan.
+	OnScoreDrop = "scoreDrop"
+	// OnNewIssues notifies when issues were introduced since the last scan.
+	OnNewIssues = "newIssues"
+	// OnNewErrors notifies when errors were introduced since the last scan.
+	OnNewErrors = "newErrors"
+
+	defaultWebhookRetries = 3
+	defaultWebhookBackoff = time.Second
+	defaultWebhookTimeout = 10 * time.Second
+)
+
+var (
+	webhookKinds = []string{WebhookJSON, WebhookSlack, WebhookTeams}
+	webhookOns   = []string{OnAlways, OnScoreDrop, OnNewIssues, OnNewErrors}
+)
+
+// Webhooks tracks a collection of webhooks.
+type Webhooks []Webhook
+
+// Webhook tracks a scan notification endpoint.
+type Webhook struct {
+	// Name identifies the webhook.
+	Name string `yaml:"name"`
+
+	// URL the webhook endpoint. Environment variables are expanded.
+	URL string `yaml:"url"`
+
+	// Kind the payload flavor ie json, slack or teams. Defaults to json.
+	Kind string `yaml:"kind,omitempty"`
+
+	// Template an optional go template rendering the payload from the scan summary.
+	Template string `yaml:"template,omitempty"`
+
+	// Headers extra request headers. Environment variables are expanded.
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// On lists the conditions triggering a notification. Defaults to always.
+	On []string `yaml:"on,omitempty"`
+
+	// Retries the number of retries on failure. Defaults to 3.
+	Retries *int `yaml:"retries,omitempty"`
+
+	// Backoff the initial delay between retries, doubled on each retry. Defaults to 1s.
+	Backoff string `yaml:"backoff,omitempty"`
+
+	// Timeout the request timeout. Defaults to 10s.
+	Timeout string `yaml:"timeout,omitempty"`
+}
+
+// Merge returns the given webhooks layered on a copy of these ones. Webhooks
+// with the same name are replaced.
+func (ww Webhooks) Merge(oo Webhooks) Webhooks {
+	return rules.MergeBy(ww, oo, func(w Webhook) string { return w.Name })
+}
+
+// Validate checks the webhook configuration.
+func (w Webhook) Validate() error {
+	if w.Name == "" {
+		return fmt.Errorf("webhook name is required")
+	}
+	if w.URL == "" {
+		return fmt.Errorf("webhook %q url is required", w.Name)
+	}
+	if w.Kind != "" && !slices.Contains(webhookKinds, w.Kind) {
+		return fmt.Errorf("webhook %q invalid kind %q. [%s]", w.Name, w.Kind, strings.Join(webhookKinds, ","))
+	}
+	for _, on := range w.On {
+		if !slices.Contains(webhookOns, on) {
+			return fmt.Errorf("webhook %q invalid condition %q. [%s]", w.Name, on, strings.Join(webhookOns, ","))
+		}
+	}
+	if w.Retries != nil && *w.Retries < 0 {
+		return fmt.Errorf("webhook %q retries must not be negative", w.Name)
+	}
+	for _, d := range [][2]string{{"backoff", w.Backoff}, {"timeout", w.Timeout}} {
+		if d[1] == "" {
+			continue
+		}
+		if _, err := time.ParseDuration(d[1]); err != nil {
+			return fmt.Errorf("webhook %q invalid %s %q", w.Name, d[0], d[1])
+		}
+	}
+
+	return nil
+}
+
+// IsConditional returns true if the webhook only fires given a prior scan.
+func (w Webhook) IsConditional() bool {
+	return slices.ContainsFunc(w.On, func(on string) bool { return on != OnAlways })
+}
+
+// Endpoint returns the webhook URL with environment variables expanded.
+func (w Webhook) Endpoint() string {
+	return os.ExpandEnv(w.URL)
+}
+
+// Header returns the request headers with environment variables expanded.
+func (w Webhook) Header() map[string]string {
+	hh := make(map[string]string, len(w.Headers))
+	for k, v := range w.Headers {
+		hh[k] = os.ExpandEnv(v)
+	}
+
+	return hh
+}
+
+// PayloadKind returns the webhook payload flavor.
+func (w Webhook) PayloadKind() string {
+	if w.Kind == "" {
+		return WebhookJSON
+	}
+
+	return w.Kind
+}
+
+// Conditions returns the notification conditions.
+func (w Webhook) Conditions() []string {
+	if len(w.On) == 0 {
+		return []string{OnAlways}
+	}
+
+	return w.On
+}
+
+// MaxRetries returns the number of retries on failure.
+func (w Webhook) MaxRetries() int {
+	if w.Retries == nil {
+		return defaultWebhookRetries
+	}
+
+	return *w.Retries
+}
+
+// BackoffDuration returns the initial delay between retries.
+func (w Webhook) BackoffDuration() time.Duration {
+	return parseDurationOr(w.Backoff, defaultWebhookBackoff)
+}
+
+// TimeoutDuration returns the request timeout.
+func (w Webhook) TimeoutDuration() time.Duration {
+	return parseDurationOr(w.Timeout, defaultWebhookTimeout)
+}
+
+func parseDurationOr(s string, d time.Duration) time.Duration {
+	if s == "" {
+		return d
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return d
+	}
+
+	return v
+}
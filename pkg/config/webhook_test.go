@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookValidate(t *testing.T) {
+	uu := map[string]struct {
+		w   Webhook
+		err string
+	}{
+		"plain": {
+			w: Webhook{Name: "w", URL: "http://fred"},
+		},
+		"full": {
+			w: Webhook{Name: "w", URL: "http://fred", Kind: WebhookSlack, On: []string{OnScoreDrop, OnNewErrors}, Backoff: "2s", Timeout: "5s"},
+		},
+		"no-name": {
+			w:   Webhook{URL: "http://fred"},
+			err: "webhook name is required",
+		},
+		"no-url": {
+			w:   Webhook{Name: "w"},
+			err: `webhook "w" url is required`,
+		},
+		"kind": {
+			w:   Webhook{Name: "w", URL: "http://fred", Kind: "discord"},
+			err: `webhook "w" invalid kind "discord". [json,slack,teams]`,
+		},
+		"on": {
+			w:   Webhook{Name: "w", URL: "http://fred", On: []string{"fred"}},
+			err: `webhook "w" invalid condition "fred". [always,scoreDrop,newIssues,newErrors]`,
+		},
+		"backoff": {
+			w:   Webhook{Name: "w", URL: "http://fred", Backoff: "soon"},
+			err: `webhook "w" invalid backoff "soon"`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			err := u.w.Validate()
+			if u.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, u.err)
+		})
+	}
+}
+
+func TestWebhookDefaults(t *testing.T) {
+	w := Webhook{Name: "w", URL: "http://fred"}
+
+	assert.Equal(t, WebhookJSON, w.PayloadKind())
+	assert.Equal(t, []string{OnAlways}, w.Conditions())
+	assert.False(t, w.IsConditional())
+	assert.Equal(t, 3, w.MaxRetries())
+	assert.Equal(t, time.Second, w.BackoffDuration())
+	assert.Equal(t, 10*time.Second, w.TimeoutDuration())
+}
+
+func TestWebhooksMerge(t *testing.T) {
+	base := Webhooks{{Name: "a", URL: "http://a"}, {Name: "b", URL: "http://b"}}
+	ww := base.Merge(Webhooks{{Name: "b", URL: "http://b2"}, {Name: "c", URL: "http://c"}})
+
+	assert.Equal(t, Webhooks{{Name: "a", URL: "http://a"}, {Name: "b", URL: "http://b2"}, {Name: "c", URL: "http://c"}}, ww)
+	assert.Equal(t, Webhooks{{Name: "a", URL: "http://a"}, {Name: "b", URL: "http://b"}}, base)
+}
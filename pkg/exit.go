@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package pkg
+
+import (
+	"fmt"
+
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/pkg/config"
+)
+
+const (
+	// ExitOK indicates a passing scan.
+	ExitOK = 0
+
+	// ExitViolation indicates a violated exit policy.
+	ExitViolation = 1
+
+	// ExitFailed indicates a failed scan.
+	ExitFailed = 2
+
+	minScorePolicy = "min-score"
+)
+
+// defaultExitPolicy fails scans reporting errors when no exit policies are given.
+var defaultExitPolicy = config.ExitPolicy{Name: "errors"}
+
+// Violations returns the exit policies violated by the last scan.
+func (p *Popeye) Violations() []report.Violation {
+	return p.builder.Violations()
+}
+
+// exitPolicies returns the spinach exit policies layered with the command line ones.
+func (p *Popeye) exitPolicies() (config.ExitPolicies, error) {
+	ff, err := p.flags.ExitPolicies()
+	if err != nil {
+		return nil, err
+	}
+	pp := append(config.ExitPolicies{}, p.config.ExitPolicies...).Merge(ff)
+	if len(pp) == 0 {
+		return config.ExitPolicies{defaultExitPolicy}, nil
+	}
+
+	return pp, nil
+}
+
+// checkExitPolicies records the exit policies violated by the scan.
+func (p *Popeye) checkExitPolicies(score int) error {
+	pp, err := p.exitPolicies()
+	if err != nil {
+		return err
+	}
+	vv := p.builder.ToScan(nil).Check(pp, p.baselineScan)
+	if p.flags.MinScore != nil && score < *p.flags.MinScore {
+		vv = append(vv, report.Violation{
+			Policy:    minScorePolicy,
+			Condition: fmt.Sprintf("cluster score below %d", *p.flags.MinScore),
+			Value:     score,
+		})
+	}
+	p.builder.SetViolations(vv)
+
+	return nil
+}
@@ -22,7 +22,7 @@ func BailOut(err error) {
 	fmt.Printf("\n\nBoom! %v (see logs)\n", err)
 	log.Error().Msgf("%v", err)
 	log.Error().Msg(string(debug.Stack()))
-	os.Exit(1)
+	os.Exit(ExitFailed)
 }
 
 func printMsgLogo(msg, eye string, title, logo report.Color) {
@@ -13,7 +13,9 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/derailed/popeye/internal"
@@ -22,16 +24,24 @@ import (
 	"github.com/derailed/popeye/internal/client"
 	"github.com/derailed/popeye/internal/db"
 	"github.com/derailed/popeye/internal/db/schema"
+	"github.com/derailed/popeye/internal/events"
+	"github.com/derailed/popeye/internal/history"
 	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/notify"
 	"github.com/derailed/popeye/internal/report"
-	"github.com/derailed/popeye/internal/rules"
 	"github.com/derailed/popeye/internal/scrub"
 	"github.com/derailed/popeye/pkg/config"
+	"github.com/derailed/popeye/pkg/storage"
 	"github.com/derailed/popeye/types"
 	"github.com/hashicorp/go-memdb"
 	"github.com/prometheus/common/expfmt"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const (
@@ -39,6 +49,12 @@ const (
 	defaultFileMode    = 0755
 	defaultInstance    = "popeye"
 	defaultGtwyTimeout = 30 * time.Second
+	fieldManager       = "popeye"
+)
+
+var (
+	policyReportGVR        = types.NewGVR("wgpolicyk8s.io/v1alpha2/policyreports")
+	clusterPolicyReportGVR = types.NewGVR("wgpolicyk8s.io/v1alpha2/clusterpolicyreports")
 )
 
 var (
@@ -50,8 +66,12 @@ var (
 )
 
 type run struct {
-	outcome issues.Outcome
-	gvr     types.GVR
+	outcome  issues.Outcome
+	skipped  []string
+	gvr      types.GVR
+	duration time.Duration
+	// fingerprints tracks all reported issues regardless of lint level.
+	fingerprints map[string][]string
 }
 
 // Popeye represents a kubernetes linter/linter.
@@ -65,6 +85,11 @@ type Popeye struct {
 	builder      *report.Builder
 	aliases      *internal.Aliases
 	codes        *issues.Codes
+	telemetry    *telemetry
+	history      *history.Store
+	baseline     *history.Scan
+	baselineScan *report.Scan
+	scanTime     time.Time
 }
 
 // NewPopeye returns a new instance.
@@ -94,6 +119,13 @@ func (p *Popeye) initDB() (*db.DB, error) {
 
 // Init configures popeye prior to sanitization.
 func (p *Popeye) Init() error {
+	if config.IsStrSet(p.flags.Baseline) {
+		s, err := report.LoadScan(*p.flags.Baseline)
+		if err != nil {
+			return err
+		}
+		p.baselineScan = s
+	}
 	if p.factory == nil {
 		if err := p.initFactory(); err != nil {
 			return err
@@ -169,28 +201,264 @@ func (p *Popeye) clusterPath() string {
 }
 
 // Lint scans a cluster for potential issues.
-func (p *Popeye) Lint() (int, int, error) {
+func (p *Popeye) Lint() (errCount int, score int, err error) {
+	var dumped bool
 	defer func() {
 		switch {
 		case config.IsBoolSet(p.flags.Save):
 			if p.outputTarget != nil {
 				p.outputTarget.Close()
 			}
+			if dumped {
+				if err := p.archiveLocal(context.Background()); err != nil {
+					log.Error().Err(err).Msg("Report archive failed")
+				}
+			}
 		case config.IsStrSet(p.flags.S3.Bucket):
-			asset := filepath.Join(p.clusterPath(), p.scanFileName())
-			if err := p.flags.S3.Upload(context.Background(), asset, p.fileContentType(), p.outputTarget); err != nil {
-				log.Fatal().Msgf("S3 upload failed: %s", err)
+			if uerr := p.upload(context.Background(), dumped); uerr != nil {
+				err = errors.Join(err, fmt.Errorf("report upload failed: %w", uerr))
 			}
 		}
 	}()
 
-	errCount, score, err := p.lint()
+	ctx := context.Background()
+	if otelEnabled(p.flags.OTLPEndpoint) {
+		t, err := newTelemetry(ctx, *p.flags.OTLPEndpoint, p.fetchClusterName())
+		if err != nil {
+			return 0, 0, fmt.Errorf("otlp exporter init failed: %w", err)
+		}
+		p.telemetry = t
+		ctx = internal.WithTracer(ctx, t.tracer())
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), defaultGtwyTimeout)
+			defer cancel()
+			if serr := t.shutdown(ctx); serr != nil {
+				err = errors.Join(err, fmt.Errorf("otlp export failed: %w", serr))
+			}
+		}()
+	}
+	ctx, span := internal.Tracer(ctx).Start(ctx, "scan")
+	defer span.End()
+
+	p.scanTime = time.Now()
+	if p.flags.TracksFindings() {
+		s, err := p.openHistory()
+		if err != nil {
+			return 0, 0, err
+		}
+		p.history = s
+		defer func() {
+			s.Close()
+			p.history = nil
+		}()
+	}
+
+	errCount, score, err = p.lint(ctx)
 	if err != nil {
+		span.RecordError(err)
 		return 0, 0, err
 	}
 	log.Debug().Msgf("Score [%d]", score)
+	span.SetAttributes(attribute.Int("popeye.score", score), attribute.Int("popeye.errors", errCount))
+	if err := p.checkExitPolicies(score); err != nil {
+		return errCount, score, err
+	}
+	if config.IsBoolSet(p.flags.History) {
+		if err := p.recordHistory(); err != nil {
+			log.Warn().Err(err).Msg("Unable to record scan history")
+		}
+	}
+
+	if err := p.dump(true, p.flags.Exhaust()); err != nil {
+		return errCount, score, err
+	}
+	dumped = true
+	if len(p.config.Webhooks) > 0 && p.builder.HasContent() {
+		if err := notify.Notify(ctx, p.config.Webhooks, notify.NewSummary(p.builder, p.baseline)); err != nil {
+			log.Error().Err(err).Msg("Webhook notifications failed")
+		}
+	}
+	if config.IsBoolSet(p.flags.PolicyReports) && p.builder.HasContent() {
+		if err := p.applyPolicyReports(ctx); err != nil {
+			log.Error().Err(err).Msg("Policy reports apply failed")
+		}
+	}
+	if config.IsBoolSet(p.flags.Events) && p.builder.HasContent() {
+		if err := p.recordEvents(ctx); err != nil {
+			log.Error().Err(err).Msg("Kubernetes events failed")
+		}
+	}
+
+	return errCount, score, p.telemetry.export(ctx, p.builder)
+}
 
-	return errCount, score, p.dump(true, p.flags.Exhaust())
+// applyPolicyReports applies the scan policy reports to the cluster.
+func (p *Popeye) applyPolicyReports(ctx context.Context) error {
+	dial, err := p.client().DynDial()
+	if err != nil {
+		return err
+	}
+	var errs error
+	for _, pr := range p.builder.ToPolicyReports(resolver{db: p.db, aliases: p.aliases}) {
+		o, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&pr)
+		if err != nil {
+			return err
+		}
+		gvr := policyReportGVR.GVR()
+		if pr.Kind == report.ClusterPolicyReportKind {
+			gvr = clusterPolicyReportGVR.GVR()
+		}
+		_, err = dial.Resource(gvr).Namespace(pr.Metadata.Namespace).Apply(
+			ctx,
+			pr.Metadata.Name,
+			&unstructured.Unstructured{Object: o},
+			metav1.ApplyOptions{FieldManager: fieldManager, Force: true},
+		)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("apply %s %s failed: %w", pr.Kind, client.FQN(pr.Metadata.Namespace, pr.Metadata.Name), err))
+		}
+	}
+
+	return errs
+}
+
+// Builder returns the scan report builder.
+func (p *Popeye) Builder() *report.Builder {
+	return p.builder
+}
+
+// SetOutputTarget redirects the scan report. It must be called after Init.
+func (p *Popeye) SetOutputTarget(w io.ReadWriteCloser) {
+	p.outputTarget = w
+}
+
+// Render renders the last scan report in the given output format.
+func (p *Popeye) Render(format string, w io.Writer) error {
+	out, target := *p.flags.Output, p.outputTarget
+	defer func() {
+		*p.flags.Output, p.outputTarget = out, target
+	}()
+
+	var buf bytes.Buffer
+	*p.flags.Output, p.outputTarget = format, NopCloser(&buf)
+	if err := p.dump(false, ""); err != nil {
+		return err
+	}
+	_, err := io.Copy(w, &buf)
+
+	return err
+}
+
+// recordEvents writes lint events on resources with issues.
+func (p *Popeye) recordEvents(ctx context.Context) error {
+	clt, err := p.client().Dial()
+	if err != nil {
+		return err
+	}
+	r := resolver{db: p.db, aliases: p.aliases}
+	n, err := events.NewRecorder(clt, r).Record(ctx, p.builder.ToScan(r))
+	log.Info().Msgf("Recorded %d lint events", n)
+
+	return err
+}
+
+// upload saves the report to the configured storage backend and indexes it
+// when the scan completed.
+func (p *Popeye) upload(ctx context.Context, archive bool) error {
+	defer p.outputTarget.Close()
+
+	opts, err := p.flags.S3.Options()
+	if err != nil {
+		return err
+	}
+	b, err := storage.New(ctx, *p.flags.S3.Bucket, opts)
+	if err != nil {
+		return err
+	}
+	dir, name := filepath.ToSlash(p.clusterPath()), p.scanFileName()
+	if err := b.Put(ctx, path.Join(dir, name), p.fileContentType(), p.outputTarget); err != nil {
+		return err
+	}
+	if !archive {
+		return nil
+	}
+	if err := p.archive(ctx, b, dir, name); err != nil {
+		log.Error().Err(err).Msg("Report archive failed")
+	}
+
+	return nil
+}
+
+// archiveLocal indexes the report saved in the dump directory.
+func (p *Popeye) archiveLocal(ctx context.Context) error {
+	dir, name := filepath.Split(*p.flags.OutputFile)
+
+	return p.archive(ctx, storage.NewDir(dir), "", name)
+}
+
+// archive records the report in the scan index and prunes reports falling
+// out of retention.
+func (p *Popeye) archive(ctx context.Context, b storage.Backend, dir, name string) error {
+	score, err := p.builder.ToScore()
+	if err != nil {
+		return err
+	}
+	e := storage.Entry{
+		Timestamp: p.scanTime.UTC(),
+		Score:     score,
+		Grade:     p.builder.Report.Grade,
+		Key:       name,
+	}
+
+	return storage.Archive(ctx, b, dir, p.fetchClusterName(), p.fetchContextName(), e, p.flags.Retention(), time.Now())
+}
+
+// HistoryFile returns the path to the local scan history store.
+func HistoryFile() string {
+	return filepath.Join(DumpDir, history.FileName)
+}
+
+func (p *Popeye) openHistory() (*history.Store, error) {
+	if err := ensureDir(DumpDir, defaultFileMode); err != nil {
+		return nil, err
+	}
+
+	return history.Open(HistoryFile())
+}
+
+func (p *Popeye) historyTarget() history.Target {
+	return history.Target{Cluster: p.fetchClusterName(), Context: p.fetchContextName()}
+}
+
+func (p *Popeye) recordHistory() error {
+	t := p.historyTarget()
+	last, err := p.history.Last(t)
+	switch {
+	case err == nil:
+		p.baseline = &last
+		p.builder.SetPreviousScore(last.Score)
+	case !errors.Is(err, history.ErrNoHistory):
+		return err
+	}
+
+	return p.history.Record(history.NewScan(p.builder, t.Cluster, t.Context, p.scanTime))
+}
+
+// ageIssues stamps the run issues with the time they were first seen and
+// drops issues younger than the requested minimum age. Issues below the lint
+// level are observed too so they are not forgotten across runs.
+func (p *Popeye) ageIssues(r run) error {
+	all := client.IsAllNamespaces(p.client().ActiveNamespace())
+	seen, err := p.history.Observe(p.historyTarget(), r.gvr.String(), r.fingerprints, all, p.scanTime)
+	if err != nil {
+		return err
+	}
+	r.outcome.Age(r.gvr.String(), seen)
+	if age := p.flags.MinIssueAge(); age > 0 {
+		r.outcome.FilterAge(p.scanTime.Add(-age))
+	}
+
+	return nil
 }
 
 func (p *Popeye) buildCtx(ctx context.Context) context.Context {
@@ -211,18 +479,54 @@ func (p *Popeye) buildCtx(ctx context.Context) context.Context {
 }
 
 func (p *Popeye) validateSpinach(ss scrub.Scrubs) error {
-	if p.flags.Spinach == nil || *p.flags.Spinach == "" {
-		return nil
-	}
 	for k := range p.config.Exclusions.Linters {
 		if _, ok := ss[internal.R(k)]; !ok {
 			return fmt.Errorf("invalid linter name specified: %q", k)
 		}
 	}
+	for k := range p.config.LintLevels {
+		if _, ok := ss[internal.R(k)]; !ok {
+			return fmt.Errorf("invalid lint level linter name specified: %q", k)
+		}
+	}
+	for k := range p.config.Scoring.Linters {
+		if _, ok := ss[internal.R(k)]; !ok {
+			return fmt.Errorf("invalid scoring linter name specified: %q", k)
+		}
+	}
+	if err := p.config.Scoring.Validate(); err != nil {
+		return err
+	}
+	for _, w := range p.config.Webhooks {
+		if err := w.Validate(); err != nil {
+			return err
+		}
+		if w.IsConditional() && !config.IsBoolSet(p.flags.History) {
+			return fmt.Errorf("webhook %q conditions require --history", w.Name)
+		}
+	}
+	pp, err := p.exitPolicies()
+	if err != nil {
+		return err
+	}
+	for _, ep := range pp {
+		if err := ep.Validate(); err != nil {
+			return err
+		}
+		if ep.Baseline && !config.IsStrSet(p.flags.Baseline) {
+			return fmt.Errorf("exit policy %q requires --baseline", ep.Name)
+		}
+	}
+	if err := p.config.Teams.Validate(); err != nil {
+		return err
+	}
+	if p.flags.IsSplit() && !p.config.Teams.IsSet() {
+		return errors.New("'--split-by team' requires teams to be configured in spinach")
+	}
 	return nil
 }
 
-func (p *Popeye) lint() (int, int, error) {
+func (p *Popeye) lint(ctx context.Context) (int, int, error) {
 	defer func(t time.Time) {
 		log.Debug().Msgf("Lint %v", time.Since(t))
 	}(time.Now())
@@ -248,7 +552,9 @@ func (p *Popeye) lint() (int, int, error) {
 		return 0, 0, err
 	}
 
-	ctx := p.buildCtx(context.Background())
+	p.builder.SetScoring(p.config.Scoring)
+	p.builder.SetProvenance(p.provenance())
+	ctx = p.buildCtx(ctx)
 	sections, ans := p.config.Sections(), p.client().ActiveNamespace()
 	nsGVR := types.NewGVR("v1/namespaces")
 	for k, fn := range scrubers {
@@ -262,7 +568,7 @@ func (p *Popeye) lint() (int, int, error) {
 		runners[gvr] = fn(ctx, cache, codes)
 	}
 
-	total, errCount := len(runners), 0
+	total, errCount, start := len(runners), 0, time.Now()
 	if total == 0 {
 		return 0, 0, fmt.Errorf("no linters matched query. check section selector")
 	}
@@ -272,23 +578,28 @@ func (p *Popeye) lint() (int, int, error) {
 		go p.runLinter(ctx, gvr, r, c, cache, codes)
 	}
 
-	var score, count int
 	for run := range c {
-		count++
+		if p.history != nil {
+			if err := p.ageIssues(run); err != nil {
+				log.Warn().Err(err).Msgf("Unable to track %s issues age", run.gvr)
+			}
+		}
 		tally := report.NewTally()
 		tally.Rollup(run.outcome)
-		score, errCount = score+tally.Score(), errCount+tally.ErrCount()
-		p.builder.AddSection(run.gvr, p.aliases.Singular(run.gvr), run.outcome, tally)
+		errCount += tally.ErrCount()
+		p.builder.AddSection(run.gvr, p.aliases.Singular(run.gvr), p.config.LintLevelFor(run.gvr.R()), run.outcome, tally)
+		p.builder.AddSkipped(run.gvr, run.skipped...)
+		p.builder.AddLinterStat(run.gvr.R(), run.gvr.String(), run.duration, len(run.outcome))
 		total--
 		if total == 0 {
 			close(c)
 		}
 	}
-	if count == 0 {
-		return errCount, 0, nil
-	}
+	p.assignTeams(ctx, cache)
+	p.builder.SetLoadStats(time.Since(start), loadStats(cache.Loader.Stats()))
+	score, err := p.builder.ToScore()
 
-	return errCount, score / count, nil
+	return errCount, score, err
 }
 
 func (p *Popeye) runLinter(ctx context.Context, gvr types.GVR, l scrub.Linter, c chan run, cache *scrub.Cache, codes *issues.Codes) {
@@ -298,18 +609,50 @@ func (p *Popeye) runLinter(ctx context.Context, gvr types.GVR, l scrub.Linter, c
 		}
 	}()
 
+	ctx, span := internal.Tracer(ctx).Start(ctx, "lint "+gvr.R(), trace.WithAttributes(
+		report.AttrLinter.String(gvr.R()),
+		report.AttrGVR.String(gvr.String()),
+	))
+	defer span.End()
+
 	if !p.aliases.IsNamespaced(gvr) {
 		ctx = context.WithValue(ctx, internal.KeyNamespace, client.ClusterScope)
 	}
+	start := time.Now()
 	if err := l.Lint(ctx); err != nil {
+		span.RecordError(err)
 		p.builder.AddError(err)
 	}
-	o := l.Outcome().Filter(rules.Level(p.config.LintLevel))
-	c <- run{gvr: gvr, outcome: o}
+	var ff map[string][]string
+	if p.history != nil {
+		ff = l.Outcome().Fingerprints(gvr.String())
+	}
+	o := l.Outcome().Filter(p.config.LintLevelFor(gvr.R()))
+	span.SetAttributes(attribute.Int("popeye.resources", len(o)))
+	skipped := l.Skipped()
+	for fqn, ii := range o {
+		if len(ii) == 0 && p.config.ExcludeFQN(gvr, fqn, nil) {
+			skipped = append(skipped, fqn)
+		}
+	}
+	slices.SortFunc(skipped, issues.SortKeys)
+	c <- run{gvr: gvr, outcome: o, skipped: skipped, duration: time.Since(start), fingerprints: ff}
+}
+
+// pivot returns the report grouped as requested by the user.
+func (p *Popeye) pivot() *report.Builder {
+	if !p.flags.IsPivoted() {
+		return p.builder
+	}
+
+	return p.builder.Pivot(*p.flags.GroupBy, func(gvr, fqn string) string {
+		r, fqn := p.db.FindOwner(p.aliases, types.NewGVR(gvr), fqn)
+		return r + "/" + fqn
+	})
 }
 
 func (p *Popeye) dumpJunit() error {
-	res, err := p.builder.ToJunit(rules.Level(p.config.LintLevel))
+	res, err := p.builder.ToJunit()
 	if err != nil {
 		return err
 	}
@@ -321,8 +664,76 @@ func (p *Popeye) dumpJunit() error {
 	return nil
 }
 
+func (p *Popeye) dumpCSV() error {
+	res, err := p.builder.ToCSV()
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(p.outputTarget, res)
+
+	return nil
+}
+
+func (p *Popeye) dumpTSV() error {
+	res, err := p.builder.ToTSV()
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(p.outputTarget, res)
+
+	return nil
+}
+
+func (p *Popeye) dumpCodeQuality() error {
+	res, err := p.builder.ToCodeQuality()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(p.outputTarget, "%v\n", res)
+
+	return nil
+}
+
+func (p *Popeye) dumpMarkdown() error {
+	res, err := p.builder.ToMarkdown(*p.flags.MarkdownMax)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(p.outputTarget, "%v\n", res)
+
+	return nil
+}
+
+func (p *Popeye) dumpSARIF() error {
+	res, err := p.builder.ToSARIF(p.codes.Glossary)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(p.outputTarget, "%v\n", res)
+
+	return nil
+}
+
+func (p *Popeye) dumpPolicyReport() error {
+	res, err := p.builder.ToPolicyReportYAML(resolver{db: p.db, aliases: p.aliases})
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(p.outputTarget, res)
+
+	return nil
+}
+
 func (p *Popeye) dumpYAML() error {
-	res, err := p.builder.ToYAML()
+	var (
+		res string
+		err error
+	)
+	if p.flags.IsLegacyReport() {
+		res, err = p.pivot().ToYAML()
+	} else {
+		res, err = p.pivot().ToScanYAML(resolver{db: p.db, aliases: p.aliases})
+	}
 	if err != nil {
 		return err
 	}
@@ -332,7 +743,15 @@ func (p *Popeye) dumpYAML() error {
 }
 
 func (p *Popeye) dumpJSON() error {
-	res, err := p.builder.ToJSON()
+	var (
+		res string
+		err error
+	)
+	if p.flags.IsLegacyReport() {
+		res, err = p.pivot().ToJSON()
+	} else {
+		res, err = p.pivot().ToScanJSON(resolver{db: p.db, aliases: p.aliases})
+	}
 	if err != nil {
 		return err
 	}
@@ -342,7 +761,7 @@ func (p *Popeye) dumpJSON() error {
 }
 
 func (p *Popeye) dumpHTML() error {
-	res, err := p.builder.ToHTML()
+	res, err := p.pivot().ToHTML()
 	if err != nil {
 		return err
 	}
@@ -371,8 +790,9 @@ func (p *Popeye) dumpStd(header bool) error {
 		p.builder.PrintHeader(s)
 	}
 	p.builder.PrintClusterInfo(s, p.client().HasMetrics())
-	p.builder.PrintReport(rules.Level(p.config.LintLevel), s)
+	p.pivot().PrintReport(s)
 	p.builder.PrintSummary(s)
+	p.builder.PrintViolations(s)
 
 	return w.Flush()
 }
@@ -452,10 +872,60 @@ func (p *Popeye) dump(printHeader bool, asset string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultGtwyTimeout)
 	defer cancel()
 	p.builder.SetClusterContext(p.fetchClusterName(), p.fetchContextName())
+	var errs error
+	if p.flags.IsSplit() {
+		errs = p.dumpTeams(ctx, printHeader, asset)
+	} else {
+		errs = p.dumpFormat(ctx, printHeader, asset)
+	}
+
+	if p.flags.OutputFormat() != report.PromFormat && config.IsStrSet(p.flags.PushGateway.URL) {
+		if config.IsStrSet(p.flags.S3.Bucket) {
+			asset = *p.flags.S3.Bucket + "/" + filepath.Join(p.clusterPath(), p.scanFileName())
+		}
+		errs = errors.Join(p.dumpPrometheus(ctx, asset, false))
+	}
+
+	return errs
+}
+
+// dumpTeams dumps out one report per owning team. YAML reports are emitted
+// as separate documents and JSON reports one per line.
+func (p *Popeye) dumpTeams(ctx context.Context, printHeader bool, asset string) error {
+	all := p.builder
+	defer func() {
+		p.builder = all
+	}()
+
+	var errs error
+	for i, b := range all.Split() {
+		if i > 0 && p.flags.OutputFormat() == report.YAMLFormat {
+			fmt.Fprintln(p.outputTarget, "---")
+		}
+		p.builder = b
+		errs = errors.Join(errs, p.dumpFormat(ctx, printHeader && i == 0, asset))
+	}
+
+	return errs
+}
+
+func (p *Popeye) dumpFormat(ctx context.Context, printHeader bool, asset string) error {
 	var errs error
 	switch p.flags.OutputFormat() {
 	case report.JunitFormat:
 		errs = errors.Join(errs, p.dumpJunit())
+	case report.CSVFormat:
+		errs = errors.Join(errs, p.dumpCSV())
+	case report.TSVFormat:
+		errs = errors.Join(errs, p.dumpTSV())
+	case report.CodeQualityFormat:
+		errs = errors.Join(errs, p.dumpCodeQuality())
+	case report.MarkdownFormat:
+		errs = errors.Join(errs, p.dumpMarkdown())
+	case report.SARIFFormat:
+		errs = errors.Join(errs, p.dumpSARIF())
+	case report.PolicyReportFormat:
+		errs = errors.Join(errs, p.dumpPolicyReport())
 	case report.YAMLFormat:
 		errs = errors.Join(errs, p.dumpYAML())
 	case report.JSONFormat:
@@ -470,13 +940,6 @@ func (p *Popeye) dump(printHeader bool, asset string) error {
 		errs = errors.Join(errs, p.dumpStd(printHeader))
 	}
 
-	if p.flags.OutputFormat() != report.PromFormat && config.IsStrSet(p.flags.PushGateway.URL) {
-		if config.IsStrSet(p.flags.S3.Bucket) {
-			asset = *p.flags.S3.Bucket + "/" + filepath.Join(p.clusterPath(), p.scanFileName())
-		}
-		errs = errors.Join(p.dumpPrometheus(ctx, asset, false))
-	}
-
 	return errs
 }
 
@@ -495,14 +958,9 @@ func (p *Popeye) ensureOutput() error {
 	)
 	switch {
 	case config.IsBoolSet(p.flags.Save):
-		dir := filepath.Join(
-			DumpDir,
-			p.clusterPath(),
-		)
-		if DumpDir != DefaultDumpDir {
-			dir = DumpDir
-		}
-
+		// Reports and their index always live in a per cluster context
+		// directory so scans from different clusters never share an index.
+		dir := filepath.Join(DumpDir, p.clusterPath())
 		if err := ensureDir(dir, defaultFileMode); err != nil {
 			return err
 		}
@@ -534,28 +992,54 @@ func (p *Popeye) scanFileName() string {
 }
 
 func (p *Popeye) fileExt() string {
-	switch *p.flags.Output {
+	return FileExt(*p.flags.Output)
+}
+
+// FileExt returns the report file extension for a given output format.
+func FileExt(format string) string {
+	switch format {
 	case "junit":
 		return "xml"
-	case "json", "yaml", "html":
-		return *p.flags.Output
+	case "json", "yaml", "html", "sarif", "csv", "tsv":
+		return format
+	case "markdown":
+		return "md"
+	case "codequality":
+		return "json"
+	case "policyreport":
+		return "yaml"
 	default:
 		return "txt"
 	}
 }
 
 func (p *Popeye) fileContentType() string {
-	switch *p.flags.Output {
+	return ContentType(*p.flags.Output)
+}
+
+// ContentType returns the report content type for a given output format.
+func ContentType(format string) string {
+	switch format {
 	case "junit":
 		// https://datatracker.ietf.org/doc/html/rfc7303#section-4.1
 		return "application/xml"
-	case "json":
+	case "json", "codequality":
 		return "application/json"
-	case "yaml":
+	case "sarif":
+		return "application/sarif+json"
+	case "yaml", "policyreport":
 		// https://datatracker.ietf.org/doc/html/rfc9512
 		return "application/yaml"
 	case "html":
 		return "text/html"
+	case "csv":
+		// https://datatracker.ietf.org/doc/html/rfc4180
+		return "text/csv"
+	case "tsv":
+		return "text/tab-separated-values"
+	case "markdown":
+		// https://datatracker.ietf.org/doc/html/rfc7763
+		return "text/markdown"
 	default:
 		return "text/plain"
 	}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package pkg
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/derailed/popeye/internal/db"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// Version the Popeye build version.
+	Version = "dev"
+
+	// Commit the Popeye build commit.
+	Commit = "dev"
+)
+
+// provenance returns how the scan was produced. The config hash matches the
+// sha256 of the `popeye config dump` output.
+func (p *Popeye) provenance() *report.Provenance {
+	pr := report.Provenance{
+		Version: Version,
+		Commit:  Commit,
+		Flags:   p.flags.UsedFlags,
+		Metrics: p.client().HasMetrics(),
+	}
+	if h, err := p.configHash(); err == nil {
+		pr.ConfigHash = h
+	} else {
+		log.Warn().Err(err).Msg("Unable to hash spinach config")
+	}
+	if v, err := p.client().ServerVersion(); err == nil && v != nil {
+		pr.ServerVersion = v.GitVersion
+	}
+
+	return &pr
+}
+
+func (p *Popeye) configHash() (string, error) {
+	h := sha256.New()
+	if err := p.config.Dump(h); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func loadStats(ll []db.LoadStat) []report.LoadStat {
+	ss := make([]report.LoadStat, 0, len(ll))
+	for _, l := range ll {
+		ss = append(ss, report.LoadStat{
+			GVR:        l.GVR.String(),
+			DurationMS: l.Duration.Milliseconds(),
+			Count:      l.Count,
+		})
+	}
+
+	return ss
+}
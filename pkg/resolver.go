@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package pkg
+
+import (
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/db"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/types"
+)
+
+// resolver looks up resource metadata for structured reports.
+type resolver struct {
+	db      *db.DB
+	aliases *internal.Aliases
+}
+
+// Kind returns the kind of a given resource.
+func (r resolver) Kind(gvr string) string {
+	return r.aliases.Kind(types.NewGVR(gvr))
+}
+
+// Controller returns the controller of a given resource if any.
+func (r resolver) Controller(gvr, fqn string) *report.OwnerRef {
+	ref := r.db.FindController(types.NewGVR(gvr), fqn)
+	if ref == nil {
+		return nil
+	}
+
+	return &report.OwnerRef{
+		APIVersion: ref.APIVersion,
+		Kind:       ref.Kind,
+		Name:       ref.Name,
+	}
+}
+
+// UID returns the uid of a given resource if any.
+func (r resolver) UID(gvr, fqn string) string {
+	return r.db.FindUID(types.NewGVR(gvr), fqn)
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	azureAccountEnv = "AZURE_STORAGE_ACCOUNT"
+	azureKeyEnv     = "AZURE_STORAGE_KEY"
+	azureSASEnv     = "AZURE_STORAGE_SAS_TOKEN"
+)
+
+// azureBackend uploads blobs using either a SAS token or a storage account
+// shared key. The bucket denotes the blob container.
+type azureBackend struct {
+	loc  Location
+	opts Options
+	clt  *azblob.Client
+}
+
+func newAzureBackend(loc Location, opts Options) (*azureBackend, error) {
+	account := os.Getenv(azureAccountEnv)
+	if account == "" {
+		return nil, fmt.Errorf("azure storage requires %s to be set", azureAccountEnv)
+	}
+	endpoint := opts.Endpoint
+	if endpoint == "" {
+		endpoint = "https://" + account + ".blob.core.windows.net"
+	}
+	ep, err := endpointURL(endpoint, opts.Insecure)
+	if err != nil {
+		return nil, err
+	}
+	ep.Path += "/"
+
+	var clt *azblob.Client
+	switch {
+	case os.Getenv(azureSASEnv) != "":
+		ep.RawQuery = strings.TrimPrefix(os.Getenv(azureSASEnv), "?")
+		clt, err = azblob.NewClientWithNoCredential(ep.String(), nil)
+	case os.Getenv(azureKeyEnv) != "":
+		cred, cerr := azblob.NewSharedKeyCredential(account, os.Getenv(azureKeyEnv))
+		if cerr != nil {
+			return nil, fmt.Errorf("invalid azure storage key: %w", cerr)
+		}
+		clt, err = azblob.NewClientWithSharedKeyCredential(ep.String(), cred, nil)
+	default:
+		return nil, fmt.Errorf("azure storage requires either %s or %s to be set", azureSASEnv, azureKeyEnv)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("azure client init failed: %w", err)
+	}
+
+	return &azureBackend{loc: loc, opts: opts, clt: clt}, nil
+}
+
+// Put uploads the object as a block blob.
+func (a *azureBackend) Put(ctx context.Context, key, contentType string, r io.Reader) error {
+	opts := azblob.UploadStreamOptions{
+		HTTPHeaders: &blob.HTTPHeaders{BlobContentType: &contentType},
+		Tags:        a.opts.Tags,
+	}
+	if a.opts.KMSKeyID != "" {
+		opts.CPKScopeInfo = &blob.CPKScopeInfo{EncryptionScope: &a.opts.KMSKeyID}
+	}
+	if _, err := a.clt.UploadStream(ctx, a.loc.Bucket, a.loc.Key(key), r, &opts); err != nil {
+		return fmt.Errorf("azure upload failed: %w", err)
+	}
+	log.Info().Msgf("Success: uploaded to container: %s/%s", a.loc.Bucket, a.loc.Key(key))
+
+	return nil
+}
+
+// Get downloads the blob content.
+func (a *azureBackend) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := a.clt.DownloadStream(ctx, a.loc.Bucket, a.loc.Key(key), nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound, bloberror.ContainerNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("azure download failed: %w", err)
+	}
+
+	return resp.Body, nil
+}
+
+// Delete removes the blob if present.
+func (a *azureBackend) Delete(ctx context.Context, key string) error {
+	if _, err := a.clt.DeleteBlob(ctx, a.loc.Bucket, a.loc.Key(key), nil); err != nil &&
+		!bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return fmt.Errorf("azure delete failed: %w", err)
+	}
+
+	return nil
+}
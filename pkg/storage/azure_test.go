@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	// Azurite well known development storage account.
+	devAccount = "devstoreaccount1"
+	devKey     = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+
+	// azuriteEnv points the Azure emulator tests to an Azurite blob endpoint
+	// ie http://127.0.0.1:10000/devstoreaccount1
+	azuriteEnv = "AZURITE_BLOB_ENDPOINT"
+)
+
+func TestAzureBackendSharedKey(t *testing.T) {
+	var (
+		req  *http.Request
+		body string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		raw, _ := io.ReadAll(r.Body)
+		body = string(raw)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	t.Setenv(azureAccountEnv, devAccount)
+	t.Setenv(azureKeyEnv, devKey)
+	t.Setenv(azureSASEnv, "")
+	b, err := New(context.Background(), "azblob://reports/popeye", Options{
+		Endpoint: srv.URL + "/" + devAccount,
+		KMSKeyID: "scope1",
+		Tags:     map[string]string{"team": "blee"},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, b.Put(context.Background(), "c1/scan.json", "application/json", strings.NewReader(`{}`)))
+
+	assert.Equal(t, http.MethodPut, req.Method)
+	assert.Equal(t, "/devstoreaccount1/reports/popeye/c1/scan.json", req.URL.Path)
+	assert.Equal(t, "BlockBlob", req.Header.Get("x-ms-blob-type"))
+	assert.Equal(t, "application/json", req.Header.Get("x-ms-blob-content-type"))
+	assert.Equal(t, "team=blee", req.Header.Get("x-ms-tags"))
+	assert.Equal(t, "scope1", req.Header.Get("x-ms-encryption-scope"))
+	assert.True(t, strings.HasPrefix(req.Header.Get("Authorization"), "SharedKey devstoreaccount1:"))
+	assert.Equal(t, `{}`, body)
+}
+
+func TestAzureBackendSAS(t *testing.T) {
+	var req *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	t.Setenv(azureAccountEnv, "fred")
+	t.Setenv(azureSASEnv, "?sv=2021-08-06&sig=blee")
+	b, err := New(context.Background(), "azblob://reports", Options{Endpoint: srv.URL})
+	assert.NoError(t, err)
+	assert.NoError(t, b.Put(context.Background(), "scan.json", "application/json", strings.NewReader(`{}`)))
+
+	assert.Equal(t, "/reports/scan.json", req.URL.Path)
+	assert.Equal(t, "blee", req.URL.Query().Get("sig"))
+	assert.Empty(t, req.Header.Get("Authorization"))
+}
+
+func TestAzureBackendNoCreds(t *testing.T) {
+	t.Setenv(azureAccountEnv, "fred")
+	t.Setenv(azureSASEnv, "")
+	t.Setenv(azureKeyEnv, "")
+	_, err := New(context.Background(), "azblob://reports", Options{})
+	assert.EqualError(t, err, "azure storage requires either AZURE_STORAGE_SAS_TOKEN or AZURE_STORAGE_KEY to be set")
+}
+
+func TestAzureBackendGetDelete(t *testing.T) {
+	var reqs []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqs = append(reqs, r.Method+" "+r.URL.Path)
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "SharedKey fred:"))
+		if r.URL.Path == "/reports/index.json" {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		w.Header().Set("x-ms-error-code", string(bloberror.BlobNotFound))
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	t.Setenv(azureAccountEnv, "fred")
+	t.Setenv(azureSASEnv, "")
+	t.Setenv(azureKeyEnv, devKey)
+	ctx := context.Background()
+	b, err := New(ctx, "azblob://reports", Options{Endpoint: srv.URL})
+	assert.NoError(t, err)
+
+	r, err := b.Get(ctx, "index.json")
+	assert.NoError(t, err)
+	r.Close()
+	_, err = b.Get(ctx, "blee.json")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NoError(t, b.Delete(ctx, "scan.json"))
+
+	assert.Equal(t, []string{
+		"GET /reports/index.json",
+		"GET /reports/blee.json",
+		"DELETE /reports/scan.json",
+	}, reqs)
+}
+
+func TestAzureEmulator(t *testing.T) {
+	ep := os.Getenv(azuriteEnv)
+	if ep == "" {
+		t.Skipf("%s is not set", azuriteEnv)
+	}
+
+	ctx := context.Background()
+	cred, err := azblob.NewSharedKeyCredential(devAccount, devKey)
+	assert.NoError(t, err)
+	clt, err := azblob.NewClientWithSharedKeyCredential(ep+"/", cred, nil)
+	assert.NoError(t, err)
+	if _, err := clt.CreateContainer(ctx, "popeye-azure", nil); err != nil {
+		assert.True(t, bloberror.HasCode(err, bloberror.ContainerAlreadyExists), err)
+	}
+
+	t.Setenv(azureAccountEnv, devAccount)
+	t.Setenv(azureKeyEnv, devKey)
+	t.Setenv(azureSASEnv, "")
+	b, err := New(ctx, "azblob://popeye-azure/reports", Options{Endpoint: ep})
+	assert.NoError(t, err)
+	assert.NoError(t, b.Put(ctx, "c1/scan.json", "application/json", strings.NewReader(`{"a":1}`)))
+
+	props, err := clt.ServiceClient().NewContainerClient("popeye-azure").NewBlobClient("reports/c1/scan.json").GetProperties(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json", *props.ContentType)
+
+	r, err := b.Get(ctx, "c1/scan.json")
+	assert.NoError(t, err)
+	raw, _ := io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, `{"a":1}`, string(raw))
+
+	assert.NoError(t, b.Delete(ctx, "c1/scan.json"))
+	assert.NoError(t, b.Delete(ctx, "c1/scan.json"))
+	_, err = b.Get(ctx, "c1/scan.json")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog/log"
+)
+
+const fileMode = 0755
+
+type fileBackend struct {
+	loc Location
+}
+
+func newFileBackend(loc Location) *fileBackend {
+	return &fileBackend{loc: loc}
+}
+
+// NewDir returns a backend storing objects in the given directory.
+func NewDir(dir string) Backend {
+	return newFileBackend(Location{Kind: FileKind, Prefix: filepath.ToSlash(dir)})
+}
+
+// Put writes the object to disk.
+func (f *fileBackend) Put(_ context.Context, key, _ string, r io.Reader) error {
+	file := f.path(key)
+	if err := os.MkdirAll(filepath.Dir(file), fileMode); err != nil {
+		return err
+	}
+	out, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	if _, err := io.Copy(out, r); err != nil {
+		return err
+	}
+	log.Info().Msgf("Success: saved %s", file)
+
+	return out.Close()
+}
+
+// Get opens the object file.
+func (f *fileBackend) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	file, err := os.Open(f.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Delete removes the object file if present.
+func (f *fileBackend) Delete(_ context.Context, key string) error {
+	if err := os.Remove(f.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (f *fileBackend) path(key string) string {
+	return filepath.Join(filepath.FromSlash(f.loc.Prefix), filepath.FromSlash(key))
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	gcs "cloud.google.com/go/storage"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/api/option"
+)
+
+type gcsBackend struct {
+	loc  Location
+	opts Options
+	clt  *gcs.Client
+}
+
+// newGCSBackend uses Google application default credentials unless an
+// emulator endpoint is specified either via the options or the
+// STORAGE_EMULATOR_HOST environment variable.
+func newGCSBackend(ctx context.Context, loc Location, opts Options) (*gcsBackend, error) {
+	oo := []option.ClientOption{gcs.WithJSONReads()}
+	if opts.Endpoint != "" {
+		ep, err := endpointURL(opts.Endpoint, opts.Insecure)
+		if err != nil {
+			return nil, err
+		}
+		oo = append(oo, option.WithEndpoint(ep.String()+"/storage/v1/"), option.WithoutAuthentication())
+	}
+	clt, err := gcs.NewClient(ctx, oo...)
+	if err != nil {
+		return nil, fmt.Errorf("gcs client init failed: %w", err)
+	}
+
+	return &gcsBackend{loc: loc, opts: opts, clt: clt}, nil
+}
+
+// Put uploads the object in a single request.
+func (g *gcsBackend) Put(ctx context.Context, key, contentType string, r io.Reader) error {
+	w := g.object(key).NewWriter(ctx)
+	w.ChunkSize = 0
+	w.ContentType = contentType
+	w.Metadata = g.opts.Tags
+	w.KMSKeyName = g.opts.KMSKeyID
+	if _, err := io.Copy(w, r); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("gcs upload failed: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("gcs upload failed: %w", err)
+	}
+	log.Info().Msgf("Success: uploaded to bucket: gs://%s/%s", g.loc.Bucket, g.loc.Key(key))
+
+	return nil
+}
+
+// Get downloads the object content.
+func (g *gcsBackend) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	r, err := g.object(key).NewReader(ctx)
+	if err != nil {
+		if errors.Is(err, gcs.ErrObjectNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("gcs download failed: %w", err)
+	}
+
+	return r, nil
+}
+
+// Delete removes the object if present.
+func (g *gcsBackend) Delete(ctx context.Context, key string) error {
+	if err := g.object(key).Delete(ctx); err != nil && !errors.Is(err, gcs.ErrObjectNotExist) {
+		return fmt.Errorf("gcs delete failed: %w", err)
+	}
+
+	return nil
+}
+
+func (g *gcsBackend) object(key string) *gcs.ObjectHandle {
+	return g.clt.Bucket(g.loc.Bucket).Object(g.loc.Key(key))
+}
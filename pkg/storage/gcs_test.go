@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	gcs "cloud.google.com/go/storage"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/option"
+)
+
+// gcsEmulatorEnv points the GCS emulator tests to a fake-gcs-server instance
+// ie docker run -p 4443:4443 fsouza/fake-gcs-server -scheme http
+const gcsEmulatorEnv = "STORAGE_EMULATOR_HOST"
+
+type gcsObject struct {
+	Name        string            `json:"name"`
+	Bucket      string            `json:"bucket"`
+	ContentType string            `json:"contentType"`
+	Metadata    map[string]string `json:"metadata"`
+}
+
+func TestGCSBackend(t *testing.T) {
+	var (
+		meta gcsObject
+		kms  string
+		body string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/upload/storage/v1/b/fred/o", r.URL.Path)
+		assert.Equal(t, "multipart", r.URL.Query().Get("uploadType"))
+		kms = r.URL.Query().Get("kmsKeyName")
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		assert.NoError(t, err)
+		mr := multipart.NewReader(r.Body, params["boundary"])
+		p, err := mr.NextPart()
+		assert.NoError(t, err)
+		assert.NoError(t, json.NewDecoder(p).Decode(&meta))
+		p, err = mr.NextPart()
+		assert.NoError(t, err)
+		assert.Equal(t, "application/json", p.Header.Get("Content-Type"))
+		raw, _ := io.ReadAll(p)
+		body = string(raw)
+		_ = json.NewEncoder(w).Encode(meta)
+	}))
+	defer srv.Close()
+
+	b, err := New(context.Background(), "gs://fred/popeye", Options{
+		Endpoint: srv.URL,
+		KMSKeyID: "projects/p/locations/l/keyRings/r/cryptoKeys/k",
+		Tags:     map[string]string{"team": "blee"},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, b.Put(context.Background(), "c1/scan.json", "application/json", strings.NewReader(`{"a":1}`)))
+
+	assert.Equal(t, gcsObject{
+		Name:        "popeye/c1/scan.json",
+		Bucket:      "fred",
+		ContentType: "application/json",
+		Metadata:    map[string]string{"team": "blee"},
+	}, meta)
+	assert.Equal(t, "projects/p/locations/l/keyRings/r/cryptoKeys/k", kms)
+	assert.Equal(t, `{"a":1}`, body)
+}
+
+func TestGCSBackendFailed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no bucket", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	b, err := New(context.Background(), "gs://fred", Options{Endpoint: srv.URL})
+	assert.NoError(t, err)
+	err = b.Put(context.Background(), "scan.json", "application/json", strings.NewReader(`{}`))
+	assert.ErrorContains(t, err, "gcs upload failed")
+	assert.ErrorContains(t, err, "no bucket")
+}
+
+func TestGCSBackendGetDelete(t *testing.T) {
+	var deleted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == "/storage/v1/b/fred/o/popeye%2Findex.json":
+			assert.Equal(t, "media", r.URL.Query().Get("alt"))
+			_, _ = w.Write([]byte(`{"scans":[]}`))
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.EscapedPath())
+			http.Error(w, "gone", http.StatusNotFound)
+		default:
+			http.Error(w, "nope", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	b, err := New(ctx, "gs://fred/popeye", Options{Endpoint: srv.URL})
+	assert.NoError(t, err)
+
+	r, err := b.Get(ctx, "index.json")
+	assert.NoError(t, err)
+	raw, _ := io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, `{"scans":[]}`, string(raw))
+
+	_, err = b.Get(ctx, "blee.json")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.NoError(t, b.Delete(ctx, "c1/scan.json"))
+	assert.Equal(t, []string{"/storage/v1/b/fred/o/popeye%2Fc1%2Fscan.json"}, deleted)
+}
+
+func TestGCSEmulator(t *testing.T) {
+	if os.Getenv(gcsEmulatorEnv) == "" {
+		t.Skipf("%s is not set", gcsEmulatorEnv)
+	}
+
+	ctx := context.Background()
+	clt, err := gcs.NewClient(ctx, option.WithoutAuthentication())
+	assert.NoError(t, err)
+	defer clt.Close()
+	bkt := clt.Bucket("popeye-gcs")
+	if _, err := bkt.Attrs(ctx); errors.Is(err, gcs.ErrBucketNotExist) {
+		assert.NoError(t, bkt.Create(ctx, "popeye", nil))
+	}
+
+	b, err := New(ctx, "gs://popeye-gcs/reports", Options{Tags: map[string]string{"team": "blee"}})
+	assert.NoError(t, err)
+	assert.NoError(t, b.Put(ctx, "c1/scan.json", "application/json", strings.NewReader(`{"a":1}`)))
+
+	attrs, err := bkt.Object("reports/c1/scan.json").Attrs(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json", attrs.ContentType)
+	assert.Equal(t, map[string]string{"team": "blee"}, attrs.Metadata)
+
+	r, err := b.Get(ctx, "c1/scan.json")
+	assert.NoError(t, err)
+	raw, _ := io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, `{"a":1}`, string(raw))
+
+	assert.NoError(t, b.Delete(ctx, "c1/scan.json"))
+	assert.NoError(t, b.Delete(ctx, "c1/scan.json"))
+	_, err = b.Get(ctx, "c1/scan.json")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// IndexFile names the scan index stored alongside a cluster context reports.
+const IndexFile = "index.json"
+
+// reportKeyRX matches the report keys retention may delete.
+var reportKeyRX = regexp.MustCompile(`^popeye-scan-[\w.-]+\.[a-z]+$`)
+
+// Entry represents an archived scan.
+type Entry struct {
+	// Timestamp the scan time.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Score the cluster score.
+	Score int `json:"score"`
+
+	// Grade the cluster grade.
+	Grade string `json:"grade"`
+
+	// Key the report object key relative to the index location.
+	Key string `json:"key"`
+}
+
+// Index lists the archived scans of a cluster context, most recent first.
+type Index struct {
+	Cluster string  `json:"cluster"`
+	Context string  `json:"context"`
+	Latest  string  `json:"latest,omitempty"`
+	Scans   []Entry `json:"scans"`
+}
+
+// Retention represents archive retention rules.
+type Retention struct {
+	// Keep the number of scans to keep. Zero keeps all scans.
+	Keep int
+
+	// MaxAge the maximum age of kept scans. Zero keeps all scans.
+	MaxAge time.Duration
+}
+
+// LoadIndex loads an index or returns an empty index if none exists yet.
+func LoadIndex(ctx context.Context, b Backend, key string) (*Index, error) {
+	r, err := b.Get(ctx, key)
+	if errors.Is(err, ErrNotFound) {
+		return &Index{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var idx Index
+	if err := json.NewDecoder(r).Decode(&idx); err != nil {
+		return nil, fmt.Errorf("invalid scan index %q: %w", key, err)
+	}
+
+	return &idx, nil
+}
+
+// Save stores the index.
+func (i *Index) Save(ctx context.Context, b Backend, key string) error {
+	raw, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return b.Put(ctx, key, "application/json", bytes.NewReader(raw))
+}
+
+// Add records a scan. A scan stored under an existing key replaces it.
+func (i *Index) Add(e Entry) {
+	for j := range i.Scans {
+		if i.Scans[j].Key == e.Key {
+			i.Scans = append(i.Scans[:j], i.Scans[j+1:]...)
+			break
+		}
+	}
+	i.Scans = append(i.Scans, e)
+	i.sort()
+}
+
+// Prune removes the scans falling out of retention and returns them.
+// The latest scan is always kept.
+func (i *Index) Prune(r Retention, now time.Time) []Entry {
+	if len(i.Scans) == 0 {
+		return nil
+	}
+
+	kept, pruned := i.Scans[:1], make([]Entry, 0)
+	for j, e := range i.Scans[1:] {
+		switch {
+		case r.Keep > 0 && j+1 >= r.Keep:
+			pruned = append(pruned, e)
+		case r.MaxAge > 0 && now.Sub(e.Timestamp) > r.MaxAge:
+			pruned = append(pruned, e)
+		default:
+			kept = append(kept, e)
+		}
+	}
+	i.Scans = kept
+
+	return pruned
+}
+
+// IsReportKey returns true if the key names a report living alongside the index.
+func IsReportKey(key string) bool {
+	return isLocalKey(key) && reportKeyRX.MatchString(key)
+}
+
+// isLocalKey returns true if the key names an object living alongside the index.
+func isLocalKey(key string) bool {
+	return key != "" && key != IndexFile && !strings.ContainsAny(key, `/\`) && !strings.Contains(key, "..")
+}
+
+func (i *Index) sort() {
+	sort.SliceStable(i.Scans, func(a, b int) bool {
+		return i.Scans[a].Timestamp.After(i.Scans[b].Timestamp)
+	})
+	i.Latest = ""
+	if len(i.Scans) > 0 {
+		i.Latest = i.Scans[0].Key
+	}
+}
+
+// Archive records the scan in the index located in dir and deletes the
+// reports falling out of retention. Reports that could not be deleted
+// remain indexed so they get pruned on the next run. Keys not naming a
+// report in dir are dropped from the index but never deleted. Reports saved
+// under a custom name are dropped quietly.
+func Archive(ctx context.Context, b Backend, dir, cluster, ctxName string, e Entry, r Retention, now time.Time) error {
+	key := path.Join(dir, IndexFile)
+	idx, err := LoadIndex(ctx, b, key)
+	if err != nil {
+		return err
+	}
+	idx.Cluster, idx.Context = cluster, ctxName
+	idx.Add(e)
+
+	var errs error
+	for _, old := range idx.Prune(r, now) {
+		switch {
+		case !isLocalKey(old.Key):
+			errs = errors.Join(errs, fmt.Errorf("refusing to prune invalid report key %q", old.Key))
+			continue
+		case !IsReportKey(old.Key):
+			continue
+		}
+		if err := b.Delete(ctx, path.Join(dir, old.Key)); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("unable to prune %q: %w", old.Key, err))
+			idx.Scans = append(idx.Scans, old)
+		}
+	}
+	idx.sort()
+
+	return errors.Join(errs, idx.Save(ctx, b, key))
+}
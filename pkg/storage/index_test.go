@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexPrune(t *testing.T) {
+	now := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	uu := map[string]struct {
+		r     Retention
+		kept  []string
+		prune []string
+	}{
+		"none": {
+			kept: []string{"d0", "d1", "d5", "d10"},
+		},
+		"keep": {
+			r:     Retention{Keep: 2},
+			kept:  []string{"d0", "d1"},
+			prune: []string{"d5", "d10"},
+		},
+		"age": {
+			r:     Retention{MaxAge: 3 * day},
+			kept:  []string{"d0", "d1"},
+			prune: []string{"d5", "d10"},
+		},
+		"both": {
+			r:     Retention{Keep: 3, MaxAge: 7 * day},
+			kept:  []string{"d0", "d1", "d5"},
+			prune: []string{"d10"},
+		},
+		"latest": {
+			r:     Retention{MaxAge: time.Hour},
+			kept:  []string{"d0"},
+			prune: []string{"d1", "d5", "d10"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			var idx Index
+			for _, d := range []int{5, 0, 10, 1} {
+				idx.Add(Entry{Timestamp: now.Add(-time.Duration(d) * day), Key: fmt.Sprintf("d%d", d)})
+			}
+			pruned := idx.Prune(u.r, now)
+
+			assert.Equal(t, u.kept, keys(idx.Scans))
+			assert.Equal(t, u.prune, keys(pruned))
+		})
+	}
+}
+
+func TestIndexAdd(t *testing.T) {
+	var (
+		idx Index
+		at  = time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	)
+	idx.Add(Entry{Timestamp: at, Key: "scan.json", Score: 80})
+	idx.Add(Entry{Timestamp: at.Add(time.Hour), Key: "scan.json", Score: 90})
+
+	assert.Equal(t, []Entry{{Timestamp: at.Add(time.Hour), Key: "scan.json", Score: 90}}, idx.Scans)
+	assert.Equal(t, "scan.json", idx.Latest)
+}
+
+func TestArchive(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		root = t.TempDir()
+		b    = NewDir(root)
+		at   = time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+		r    = Retention{Keep: 2}
+	)
+	for i := range 3 {
+		name := fmt.Sprintf("popeye-scan-all-%d.json", i)
+		assert.NoError(t, b.Put(ctx, "c1/ctx1/"+name, "application/json", strings.NewReader(`{}`)))
+		e := Entry{Timestamp: at.Add(time.Duration(i) * time.Hour), Score: 90 + i, Grade: "A", Key: name}
+		assert.NoError(t, Archive(ctx, b, "c1/ctx1", "c1", "ctx1", e, r, at))
+	}
+
+	idx, err := LoadIndex(ctx, b, "c1/ctx1/"+IndexFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "c1", idx.Cluster)
+	assert.Equal(t, "ctx1", idx.Context)
+	assert.Equal(t, "popeye-scan-all-2.json", idx.Latest)
+	assert.Equal(t, []string{"popeye-scan-all-2.json", "popeye-scan-all-1.json"}, keys(idx.Scans))
+	assert.Equal(t, 92, idx.Scans[0].Score)
+
+	_, err = os.Stat(filepath.Join(root, "c1", "ctx1", "popeye-scan-all-0.json"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(root, "c1", "ctx1", "popeye-scan-all-1.json"))
+	assert.NoError(t, err)
+}
+
+func TestArchiveInvalidKey(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		root = t.TempDir()
+		b    = NewDir(root)
+		at   = time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	)
+	assert.NoError(t, b.Put(ctx, "c2/ctx2/popeye-scan-all-1.json", "application/json", strings.NewReader(`{}`)))
+	idx := Index{Scans: []Entry{{Timestamp: at.Add(-time.Hour), Key: "../../c2/ctx2/popeye-scan-all-1.json"}}}
+	assert.NoError(t, idx.Save(ctx, b, "c1/ctx1/"+IndexFile))
+
+	e := Entry{Timestamp: at, Key: "popeye-scan-all-2.json"}
+	err := Archive(ctx, b, "c1/ctx1", "c1", "ctx1", e, Retention{Keep: 1}, at)
+	assert.EqualError(t, err, `refusing to prune invalid report key "../../c2/ctx2/popeye-scan-all-1.json"`)
+
+	_, err = os.Stat(filepath.Join(root, "c2", "ctx2", "popeye-scan-all-1.json"))
+	assert.NoError(t, err)
+	got, err := LoadIndex(ctx, b, "c1/ctx1/"+IndexFile)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"popeye-scan-all-2.json"}, keys(got.Scans))
+}
+
+func TestArchiveCustomName(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		root = t.TempDir()
+		b    = NewDir(root)
+		at   = time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+		r    = Retention{Keep: 1}
+	)
+	for i := range 2 {
+		assert.NoError(t, b.Put(ctx, "c1/ctx1/report.html", "text/html", strings.NewReader(`<html/>`)))
+		e := Entry{Timestamp: at.Add(time.Duration(i) * time.Hour), Key: "report.html"}
+		assert.NoError(t, Archive(ctx, b, "c1/ctx1", "c1", "ctx1", e, r, at))
+	}
+	idx, err := LoadIndex(ctx, b, "c1/ctx1/"+IndexFile)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"report.html"}, keys(idx.Scans))
+
+	assert.NoError(t, b.Put(ctx, "c1/ctx1/popeye-scan-all-1.json", "application/json", strings.NewReader(`{}`)))
+	e := Entry{Timestamp: at.Add(2 * time.Hour), Key: "popeye-scan-all-1.json"}
+	assert.NoError(t, Archive(ctx, b, "c1/ctx1", "c1", "ctx1", e, r, at))
+
+	idx, err = LoadIndex(ctx, b, "c1/ctx1/"+IndexFile)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"popeye-scan-all-1.json"}, keys(idx.Scans))
+	_, err = os.Stat(filepath.Join(root, "c1", "ctx1", "report.html"))
+	assert.NoError(t, err)
+}
+
+func TestIsReportKey(t *testing.T) {
+	uu := map[string]struct {
+		key string
+		e   bool
+	}{
+		"report":    {key: "popeye-scan-all-1710028800.json", e: true},
+		"operator":  {key: "popeye-scan-1710028800.html", e: true},
+		"nested":    {key: "ns/popeye-scan-1.json"},
+		"traversal": {key: "../popeye-scan-1.json"},
+		"dots":      {key: "popeye-scan-..json"},
+		"backslash": {key: `..\popeye-scan-1.json`},
+		"index":     {key: IndexFile},
+		"other":     {key: "fred.json"},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, IsReportKey(u.key))
+		})
+	}
+}
+
+func TestLoadIndexMissing(t *testing.T) {
+	idx, err := LoadIndex(context.Background(), NewDir(t.TempDir()), IndexFile)
+
+	assert.NoError(t, err)
+	assert.Empty(t, idx.Scans)
+}
+
+func keys(ee []Entry) []string {
+	if len(ee) == 0 {
+		return nil
+	}
+	kk := make([]string, 0, len(ee))
+	for _, e := range ee {
+		kk = append(kk, e.Key)
+	}
+
+	return kk
+}
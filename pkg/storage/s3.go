@@ -0,0 +1,237 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go/logging"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/rs/zerolog/log"
+)
+
+type s3Logger struct{}
+
+func (s *s3Logger) Log(mm ...any) {
+	for _, m := range mm {
+		log.Debug().Msgf("S3 %s", m)
+	}
+}
+
+func (s *s3Logger) Logf(classification logging.Classification, format string, v ...interface{}) {
+	log.Debug().Msgf("[AWS] %s", v)
+}
+
+type s3Backend struct {
+	loc  Location
+	opts Options
+	clt  *s3.Client
+}
+
+func newS3Backend(ctx context.Context, loc Location, opts Options) (*s3Backend, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion(opts.Region),
+		config.WithLogConfigurationWarnings(true),
+		config.WithLogger(&s3Logger{}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var ep *url.URL
+	if opts.Endpoint != "" {
+		if ep, err = endpointURL(opts.Endpoint, opts.Insecure); err != nil {
+			return nil, err
+		}
+	}
+	clt := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if ep != nil {
+			o.BaseEndpoint, o.UsePathStyle = aws.String(ep.String()), true
+		}
+	})
+
+	return &s3Backend{loc: loc, opts: opts, clt: clt}, nil
+}
+
+// Put uploads the object to an S3 bucket, creating the bucket if needed.
+func (s *s3Backend) Put(ctx context.Context, key, contentType string, r io.Reader) error {
+	bucket := s.loc.Bucket
+	opts := s3.CreateBucketInput{Bucket: &bucket}
+	if s.opts.Region != "" && s.opts.Region != "us-east-1" {
+		opts.CreateBucketConfiguration = &types.CreateBucketConfiguration{
+			LocationConstraint: types.BucketLocationConstraint(s.opts.Region),
+		}
+	}
+	if _, err := s.clt.CreateBucket(ctx, &opts); err != nil {
+		var (
+			exists *types.BucketAlreadyExists
+			owned  *types.BucketAlreadyOwnedByYou
+		)
+		switch {
+		case errors.As(err, &exists):
+			log.Info().Msgf("bucket %s already exists", bucket)
+		case errors.As(err, &owned):
+			log.Info().Msgf("bucket %s already owned by you", bucket)
+		default:
+			log.Err(err).Msgf("failed to create bucket %s", bucket)
+			return err
+		}
+	}
+
+	path := s.loc.Key(key)
+	in := s3.PutObjectInput{
+		Bucket:      &bucket,
+		Key:         &path,
+		Body:        r,
+		ContentType: aws.String(contentType),
+	}
+	if s.opts.SSE != "" {
+		in.ServerSideEncryption = types.ServerSideEncryption(s.opts.SSE)
+	}
+	if s.opts.KMSKeyID != "" {
+		in.SSEKMSKeyId = aws.String(s.opts.KMSKeyID)
+	}
+	if len(s.opts.Tags) > 0 {
+		in.Tagging = aws.String(encodeTags(s.opts.Tags))
+	}
+	if _, err := manager.NewUploader(s.clt).Upload(ctx, &in); err != nil {
+		log.Err(err).Msgf("failed to upload to bucket: %s//%s", bucket, path)
+		return err
+	}
+	log.Info().Msgf("Success: uploaded to bucket: %s//%s", bucket, path)
+
+	return nil
+}
+
+// Get downloads the object.
+func (s *s3Backend) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	out, err := s.clt.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.loc.Bucket),
+		Key:    aws.String(s.loc.Key(key)),
+	})
+	if err != nil {
+		var noKey *types.NoSuchKey
+		if errors.As(err, &noKey) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return out.Body, nil
+}
+
+// Delete removes the object if present.
+func (s *s3Backend) Delete(ctx context.Context, key string) error {
+	_, err := s.clt.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.loc.Bucket),
+		Key:    aws.String(s.loc.Key(key)),
+	})
+
+	return err
+}
+
+type minioBackend struct {
+	loc  Location
+	opts Options
+	clt  *minio.Client
+}
+
+func newMinioBackend(_ context.Context, loc Location, opts Options) (*minioBackend, error) {
+	if opts.Endpoint == "" {
+		return nil, errors.New("minio storage requires an endpoint")
+	}
+	ep, err := endpointURL(opts.Endpoint, opts.Insecure)
+	if err != nil {
+		return nil, err
+	}
+	clt, err := minio.New(ep.Host, &minio.Options{
+		Creds: credentials.NewStaticV4(
+			os.Getenv("AWS_ACCESS_KEY_ID"),
+			os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			""),
+		Secure: ep.Scheme == "https",
+		Region: opts.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &minioBackend{loc: loc, opts: opts, clt: clt}, nil
+}
+
+// Put uploads the object to a MinIO bucket, creating the bucket if needed.
+func (m *minioBackend) Put(ctx context.Context, key, contentType string, r io.Reader) error {
+	bucket := m.loc.Bucket
+	if err := m.clt.MakeBucket(ctx, bucket, minio.MakeBucketOptions{Region: m.opts.Region}); err != nil {
+		exists, errBucketExists := m.clt.BucketExists(ctx, bucket)
+		if errBucketExists != nil || !exists {
+			return err
+		}
+		log.Debug().Msgf("Bucket %s already exists", bucket)
+	}
+
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	opts := minio.PutObjectOptions{ContentType: contentType, UserTags: m.opts.Tags}
+	switch {
+	case m.opts.KMSKeyID != "":
+		if opts.ServerSideEncryption, err = encrypt.NewSSEKMS(m.opts.KMSKeyID, nil); err != nil {
+			return err
+		}
+	case m.opts.SSE != "":
+		opts.ServerSideEncryption = encrypt.NewSSE()
+	}
+	path := m.loc.Key(key)
+	info, err := m.clt.PutObject(ctx, bucket, path, bytes.NewReader(raw), int64(len(raw)), opts)
+	if err != nil {
+		return err
+	}
+	log.Info().Msgf("Success: uploaded %s of size %d", path, info.Size)
+
+	return nil
+}
+
+// Get downloads the object.
+func (m *minioBackend) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	obj, err := m.clt.GetObject(ctx, m.loc.Bucket, m.loc.Key(key), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// Delete removes the object if present.
+func (m *minioBackend) Delete(ctx context.Context, key string) error {
+	return m.clt.RemoveObject(ctx, m.loc.Bucket, m.loc.Key(key), minio.RemoveObjectOptions{})
+}
+
+func encodeTags(tags map[string]string) string {
+	vv := make(url.Values, len(tags))
+	for k, v := range tags {
+		vv.Set(k, v)
+	}
+
+	return vv.Encode()
+}
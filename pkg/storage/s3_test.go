@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeS3 struct {
+	mx   sync.Mutex
+	puts map[string]http.Header
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+	if r.Method == http.MethodPut && strings.Count(strings.Trim(r.URL.Path, "/"), "/") > 0 {
+		f.puts[r.URL.Path] = r.Header.Clone()
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestS3Backend(t *testing.T) {
+	uu := map[string]struct {
+		uri  string
+		opts Options
+		key  string
+		e    http.Header
+	}{
+		"s3": {
+			uri: "s3://fred/popeye",
+			opts: Options{
+				SSE:      "aws:kms",
+				KMSKeyID: "k1",
+				Tags:     map[string]string{"team": "blee"},
+			},
+			key: "/fred/popeye/c1/scan.json",
+			e: http.Header{
+				"Content-Type":                                {"application/json"},
+				"X-Amz-Server-Side-Encryption":                {"aws:kms"},
+				"X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id": {"k1"},
+				"X-Amz-Tagging":                               {"team=blee"},
+			},
+		},
+		"minio": {
+			uri: "minio://fred",
+			opts: Options{
+				Tags: map[string]string{"team": "blee"},
+			},
+			key: "/fred/c1/scan.json",
+			e: http.Header{
+				"Content-Type":  {"application/json"},
+				"X-Amz-Tagging": {"team=blee"},
+			},
+		},
+	}
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "fred")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "blee")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			f := fakeS3{puts: make(map[string]http.Header)}
+			srv := httptest.NewServer(&f)
+			defer srv.Close()
+
+			u.opts.Region, u.opts.Endpoint = "us-east-1", srv.URL
+			b, err := New(context.Background(), u.uri, u.opts)
+			assert.NoError(t, err)
+			assert.NoError(t, b.Put(context.Background(), "c1/scan.json", "application/json", strings.NewReader(`{}`)))
+
+			hh, ok := f.puts[u.key]
+			assert.True(t, ok)
+			for h, v := range u.e {
+				assert.Equal(t, v, hh.Values(h), h)
+			}
+		})
+	}
+}
+
+func TestMinioBackendNoEndpoint(t *testing.T) {
+	_, err := New(context.Background(), "minio://fred", Options{})
+	assert.EqualError(t, err, "minio storage requires an endpoint")
+}
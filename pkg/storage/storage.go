@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Kind represents a storage backend kind.
+type Kind string
+
+const (
+	// FileKind stores reports on the local file system.
+	FileKind Kind = "file"
+	// S3Kind stores reports in AWS S3.
+	S3Kind Kind = "s3"
+	// MinioKind stores reports in a MinIO object store.
+	MinioKind Kind = "minio"
+	// GCSKind stores reports in Google Cloud Storage.
+	GCSKind Kind = "gs"
+	// AzureKind stores reports in Azure Blob Storage.
+	AzureKind Kind = "azblob"
+)
+
+// ErrNotFound indicates the requested object does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// Backend represents a report store.
+type Backend interface {
+	// Put stores an object under the given key.
+	Put(ctx context.Context, key, contentType string, r io.Reader) error
+
+	// Get returns the object stored under the given key or ErrNotFound.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the object stored under the given key if present.
+	Delete(ctx context.Context, key string) error
+}
+
+// Options tracks storage backend options.
+type Options struct {
+	// Region the bucket region if any.
+	Region string
+
+	// Endpoint overrides the backend service endpoint ie an emulator.
+	Endpoint string
+
+	// Insecure disables TLS when the endpoint does not specify a scheme.
+	Insecure bool
+
+	// SSE the server side encryption algorithm ie AES256 or aws:kms.
+	SSE string
+
+	// KMSKeyID the encryption key id, key name or encryption scope.
+	KMSKeyID string
+
+	// Tags tracks object tags.
+	Tags map[string]string
+}
+
+var allOptions = []string{"region", "endpoint", "insecure", "sse", "kms-key", "tags"}
+
+// supportedOptions tracks the options honored by each storage kind.
+var supportedOptions = map[Kind][]string{
+	FileKind:  nil,
+	S3Kind:    allOptions,
+	MinioKind: allOptions,
+	GCSKind:   {"endpoint", "insecure", "kms-key", "tags"},
+	AzureKind: {"endpoint", "insecure", "kms-key", "tags"},
+}
+
+// Check returns an error when options the given storage kind does not
+// support are set.
+func (o Options) Check(k Kind) error {
+	set := map[string]bool{
+		"region":   o.Region != "",
+		"endpoint": o.Endpoint != "",
+		"insecure": o.Insecure,
+		"sse":      o.SSE != "",
+		"kms-key":  o.KMSKeyID != "",
+		"tags":     len(o.Tags) > 0,
+	}
+	var uu []string
+	for _, n := range allOptions {
+		if set[n] && !slices.Contains(supportedOptions[k], n) {
+			uu = append(uu, n)
+		}
+	}
+	if len(uu) > 0 {
+		return fmt.Errorf("%s storage does not support options: %s", k, strings.Join(uu, ","))
+	}
+
+	return nil
+}
+
+// Location represents a parsed storage URI.
+type Location struct {
+	Kind   Kind
+	Bucket string
+	Prefix string
+}
+
+// Key returns the full object key for the given asset.
+func (l Location) Key(asset string) string {
+	return strings.TrimPrefix(path.Join(l.Prefix, asset), "/")
+}
+
+// New returns a storage backend given a storage URI.
+func New(ctx context.Context, uri string, opts Options) (Backend, error) {
+	loc, err := Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if err := opts.Check(loc.Kind); err != nil {
+		return nil, err
+	}
+
+	switch loc.Kind {
+	case FileKind:
+		return newFileBackend(loc), nil
+	case S3Kind:
+		return newS3Backend(ctx, loc, opts)
+	case MinioKind:
+		return newMinioBackend(ctx, loc, opts)
+	case GCSKind:
+		return newGCSBackend(ctx, loc, opts)
+	case AzureKind:
+		return newAzureBackend(loc, opts)
+	default:
+		return nil, fmt.Errorf("unsupported storage: %s", loc.Kind)
+	}
+}
+
+// Parse parses a storage URI. URIs without a scheme denote S3 buckets.
+func Parse(uri string) (Location, error) {
+	if uri == "" {
+		return Location{}, fmt.Errorf("invalid storage URI: %q", uri)
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return Location{}, err
+	}
+
+	switch Kind(u.Scheme) {
+	case FileKind:
+		if u.Host != "" || u.Path == "" {
+			return Location{}, fmt.Errorf("invalid storage URI: %q. Use file:///abs/path", u.String())
+		}
+		return Location{Kind: FileKind, Prefix: path.Clean(u.Path)}, nil
+
+	case S3Kind, MinioKind, GCSKind, AzureKind:
+		if u.Host == "" {
+			return Location{}, fmt.Errorf("invalid storage URI: %q", u.String())
+		}
+		return Location{Kind: Kind(u.Scheme), Bucket: u.Host, Prefix: strings.Trim(u.Path, "/")}, nil
+
+	case "":
+		tokens := strings.SplitAfterN(strings.Trim(u.Path, "/"), "/", 2)
+		if len(tokens) == 0 || tokens[0] == "" {
+			return Location{}, fmt.Errorf("invalid storage URI: %q", u.String())
+		}
+		loc := Location{Kind: S3Kind, Bucket: strings.Trim(tokens[0], "/")}
+		if len(tokens) > 1 {
+			loc.Prefix = tokens[1]
+		}
+		return loc, nil
+
+	default:
+		return Location{}, fmt.Errorf("invalid storage URI: %q", u.String())
+	}
+}
+
+// endpointURL returns the endpoint as a URL. Endpoints without a scheme use
+// https unless insecure is set.
+func endpointURL(endpoint string, insecure bool) (*url.URL, error) {
+	if !strings.Contains(endpoint, "://") {
+		scheme := "https"
+		if insecure {
+			scheme = "http"
+		}
+		endpoint = scheme + "://" + endpoint
+	}
+
+	return url.Parse(strings.TrimSuffix(endpoint, "/"))
+}
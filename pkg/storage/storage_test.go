@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	var uu = map[string]struct {
+		uri string
+		loc Location
+		err error
+	}{
+		"empty": {
+			err: errors.New(`invalid storage URI: ""`),
+		},
+
+		"no-scheme": {
+			uri: ":bozo",
+			err: &url.Error{Op: "parse", URL: ":bozo", Err: errors.New("missing protocol scheme")},
+		},
+
+		"s3_bucket": {
+			uri: "s3://bucketName/",
+			loc: Location{Kind: S3Kind, Bucket: "bucketName"},
+		},
+
+		"s3-toast": {
+			uri: "s4://bucketName/",
+			err: errors.New(`invalid storage URI: "s4://bucketName/"`),
+		},
+
+		"s3-with_full_key": {
+			uri: "s3://bucketName/fred/blee",
+			loc: Location{Kind: S3Kind, Bucket: "bucketName", Prefix: "fred/blee"},
+		},
+
+		"s3-with_key": {
+			uri: "bucket/with/subkey",
+			loc: Location{Kind: S3Kind, Bucket: "bucket", Prefix: "with/subkey"},
+		},
+
+		"s3-with_trailer": {
+			uri: "/bucket/with/leading/slashes/",
+			loc: Location{Kind: S3Kind, Bucket: "bucket", Prefix: "with/leading/slashes"},
+		},
+
+		"blee": {
+			uri: "my-bucket/popeye/my-cluster/2025/01/27/",
+			loc: Location{Kind: S3Kind, Bucket: "my-bucket", Prefix: "popeye/my-cluster/2025/01/27"},
+		},
+
+		"minio": {
+			uri: "minio://fred/blee/",
+			loc: Location{Kind: MinioKind, Bucket: "fred", Prefix: "blee"},
+		},
+
+		"minio-with_key": {
+			uri: "minio://fred/blee/a/b.json",
+			loc: Location{Kind: MinioKind, Bucket: "fred", Prefix: "blee/a/b.json"},
+		},
+
+		"gcs": {
+			uri: "gs://fred/blee/",
+			loc: Location{Kind: GCSKind, Bucket: "fred", Prefix: "blee"},
+		},
+
+		"azure": {
+			uri: "azblob://container/a/b",
+			loc: Location{Kind: AzureKind, Bucket: "container", Prefix: "a/b"},
+		},
+
+		"azure-no-container": {
+			uri: "azblob:///a/b",
+			err: errors.New(`invalid storage URI: "azblob:///a/b"`),
+		},
+
+		"file": {
+			uri: "file:///tmp/popeye/",
+			loc: Location{Kind: FileKind, Prefix: "/tmp/popeye"},
+		},
+
+		"file-relative": {
+			uri: "file://tmp/popeye",
+			err: errors.New(`invalid storage URI: "file://tmp/popeye". Use file:///abs/path`),
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			loc, err := Parse(u.uri)
+
+			assert.Equal(t, u.err, err)
+			assert.Equal(t, u.loc, loc)
+		})
+	}
+}
+
+func TestLocationKey(t *testing.T) {
+	assert.Equal(t, "a/b/c1/ctx1/scan.json", Location{Prefix: "a/b"}.Key("c1/ctx1/scan.json"))
+	assert.Equal(t, "scan.json", Location{}.Key("scan.json"))
+}
+
+func TestFileBackend(t *testing.T) {
+	dir := t.TempDir()
+	b, err := New(context.Background(), "file://"+filepath.ToSlash(dir)+"/reports", Options{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, b.Put(context.Background(), "c1/ctx1/scan.json", "application/json", strings.NewReader("{}")))
+	raw, err := os.ReadFile(filepath.Join(dir, "reports", "c1", "ctx1", "scan.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", string(raw))
+}
+
+func TestOptionsCheck(t *testing.T) {
+	uu := map[string]struct {
+		uri  string
+		opts Options
+		err  string
+	}{
+		"s3": {
+			uri:  "s3://fred",
+			opts: Options{Region: "us-east-1", SSE: "aws:kms", KMSKeyID: "k1", Tags: map[string]string{"a": "b"}},
+		},
+		"gcs": {
+			uri:  "gs://fred",
+			opts: Options{Endpoint: "localhost:4443", Insecure: true, KMSKeyID: "k1"},
+		},
+		"gcs-sse": {
+			uri:  "gs://fred",
+			opts: Options{Region: "us-east-1", SSE: "AES256"},
+			err:  "gs storage does not support options: region,sse",
+		},
+		"azure-region": {
+			uri:  "azblob://fred",
+			opts: Options{Region: "eastus"},
+			err:  "azblob storage does not support options: region",
+		},
+		"file": {
+			uri:  "file:///tmp/popeye",
+			opts: Options{Endpoint: "localhost", Tags: map[string]string{"a": "b"}},
+			err:  "file storage does not support options: endpoint,tags",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			loc, err := Parse(u.uri)
+			assert.NoError(t, err)
+			err = u.opts.Check(loc.Kind)
+			if u.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, u.err)
+		})
+	}
+}
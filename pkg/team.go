@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package pkg
+
+import (
+	"context"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/db"
+	"github.com/derailed/popeye/internal/scrub"
+	"github.com/derailed/popeye/types"
+	"github.com/rs/zerolog/log"
+	v1 "k8s.io/api/core/v1"
+)
+
+// assignTeams resolves resource ownership when teams are configured.
+// Namespaces are loaded so their labels can be matched even when the
+// namespace linter did not run.
+func (p *Popeye) assignTeams(ctx context.Context, cache *scrub.Cache) {
+	if !p.config.Teams.IsSet() {
+		return
+	}
+	if err := db.LoadResource[*v1.Namespace](ctx, cache.Loader, internal.Glossary[internal.NS]); err != nil {
+		log.Warn().Err(err).Msg("Unable to load namespaces. Teams will be resolved without namespace labels")
+	}
+	p.builder.SetTeams(p.teamOf)
+}
+
+// teamOf returns the team owning a given linter resource.
+func (p *Popeye) teamOf(gvr, fqn string) string {
+	g := types.NewGVR(gvr)
+	nsGVR := internal.Glossary[internal.NS]
+	ns, _ := client.Namespaced(fqn)
+	if g.String() == nsGVR.String() {
+		ns = fqn
+	}
+	var nsLabels map[string]string
+	if ns != "" {
+		nsLabels = p.db.FindLabels(nsGVR, ns)
+	}
+
+	return p.config.Teams.Owner(ns, nsLabels, p.db.FindLabels(g, fqn))
+}
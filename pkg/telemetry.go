@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Popeye
+
+package pkg
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/report"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// otelEndpointEnv is the standard OTLP exporter endpoint env var.
+const otelEndpointEnv = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+// telemetry tracks OTLP traces, logs and metrics providers.
+type telemetry struct {
+	tp *sdktrace.TracerProvider
+	lp *sdklog.LoggerProvider
+	mp *sdkmetric.MeterProvider
+}
+
+// otelEnabled checks if an OTLP endpoint was specified via flag or env.
+func otelEnabled(endpoint *string) bool {
+	return (endpoint != nil && *endpoint != "") || os.Getenv(otelEndpointEnv) != ""
+}
+
+// newTelemetry initializes OTLP/HTTP exporters. When no endpoint is given,
+// exporters fall back to the standard OTEL_EXPORTER_OTLP_* env vars.
+func newTelemetry(ctx context.Context, endpoint, cluster string) (*telemetry, error) {
+	var (
+		topts []otlptracehttp.Option
+		lopts []otlploghttp.Option
+		mopts []otlpmetrichttp.Option
+	)
+	if endpoint != "" {
+		// Like OTEL_EXPORTER_OTLP_ENDPOINT, the endpoint is a base URL for all signals.
+		base := strings.TrimSuffix(endpoint, "/")
+		topts = append(topts, otlptracehttp.WithEndpointURL(base+"/v1/traces"))
+		lopts = append(lopts, otlploghttp.WithEndpointURL(base+"/v1/logs"))
+		mopts = append(mopts, otlpmetrichttp.WithEndpointURL(base+"/v1/metrics"))
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", "popeye"),
+		report.AttrCluster.String(cluster),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	te, err := otlptracehttp.New(ctx, topts...)
+	if err != nil {
+		return nil, err
+	}
+	le, err := otlploghttp.New(ctx, lopts...)
+	if err != nil {
+		return nil, err
+	}
+	me, err := otlpmetrichttp.New(ctx, mopts...)
+	if err != nil {
+		return nil, err
+	}
+
+	t := telemetry{
+		tp: sdktrace.NewTracerProvider(sdktrace.WithBatcher(te), sdktrace.WithResource(res)),
+		lp: sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewBatchProcessor(le)), sdklog.WithResource(res)),
+		mp: sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(me)), sdkmetric.WithResource(res)),
+	}
+
+	return &t, nil
+}
+
+// tracer returns the scan tracer.
+func (t *telemetry) tracer() trace.Tracer {
+	return t.tp.Tracer(internal.TracerName)
+}
+
+// export emits the scan report issues and tallies.
+func (t *telemetry) export(ctx context.Context, b *report.Builder) error {
+	if t == nil {
+		return nil
+	}
+
+	return b.ToOTel(ctx, t.lp.Logger(internal.TracerName), t.mp.Meter(internal.TracerName))
+}
+
+// shutdown flushes all pending telemetry.
+func (t *telemetry) shutdown(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+
+	return errors.Join(
+		t.tp.Shutdown(ctx),
+		t.lp.Shutdown(ctx),
+		t.mp.Shutdown(ctx),
+	)
+}